@@ -0,0 +1,45 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// actionsCommands maps a fail condition level to the workflow command that
+// raises an annotation of that severity
+var actionsCommands = map[string]string{
+	"error":   "error",
+	"warning": "warning",
+	"note":    "notice",
+}
+
+// outputActionsAnnotations reports every fail condition that matches as a
+// GitHub Actions workflow command, so findings show up as annotations on
+// the run and in the pull request checks. The report itself is not printed.
+func outputActionsAnnotations(w io.Writer, governances []*GovernanceConfig) error {
+	for _, governance := range governances {
+		repo := fmt.Sprintf("%s/%s", governance.Repository.Owner, governance.Repository.Name)
+		for _, key := range checkFailConditions(governance, allFailConditionKeys()) {
+			condition := failConditionByKey(key)
+			title := fmt.Sprintf("%s: %s", repo, condition.key)
+			message := fmt.Sprintf("%s in %s", condition.description, repo)
+			if _, err := fmt.Fprintf(w, "::%s title=%s::%s\n", actionsCommands[condition.level], escapeActionsProperty(title), escapeActionsData(message)); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// escapeActionsData escapes a workflow command message, which ends at the
+// first newline
+func escapeActionsData(value string) string {
+	return strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A").Replace(value)
+}
+
+// escapeActionsProperty escapes a workflow command property value, where
+// ':' and ',' also separate properties
+func escapeActionsProperty(value string) string {
+	return strings.NewReplacer(":", "%3A", ",", "%2C").Replace(escapeActionsData(value))
+}
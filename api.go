@@ -1,13 +1,97 @@
 package main
 
 import (
+	"encoding/base64"
+	"errors"
 	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/cli/go-gh/v2/pkg/api"
+	"github.com/jefeish/gh-repo-inspect/utils"
+	"gopkg.in/yaml.v3"
 )
 
-func getRepositorySettings(client api.RESTClient, owner, repo string, governance *GovernanceConfig) error {
+// apiClient is the subset of api.RESTClient the fetchers rely on, so the
+// client can be wrapped (for example by the response cache)
+type apiClient interface {
+	Get(path string, response interface{}) error
+}
+
+// perPage is the largest page size the list endpoints accept
+const perPage = 100
+
+// getPaginated walks every page of a list endpoint, handing each page to
+// appendPage until a short page signals the end of the list
+func getPaginated[T any](client apiClient, path string, appendPage func(page []T)) error {
+	separator := "?"
+	if strings.Contains(path, "?") {
+		separator = "&"
+	}
+
+	for page := 1; ; page++ {
+		var items []T
+		err := client.Get(fmt.Sprintf("%s%sper_page=%d&page=%d", path, separator, perPage, page), &items)
+		if err != nil {
+			return err
+		}
+
+		appendPage(items)
+
+		if len(items) < perPage {
+			return nil
+		}
+	}
+}
+
+// isNotFound reports whether err is a 404 response from the GitHub API
+func isNotFound(err error) bool {
+	var httpErr *api.HTTPError
+	return errors.As(err, &httpErr) && httpErr.StatusCode == http.StatusNotFound
+}
+
+// isGone reports whether err is a 410 response for a retired endpoint
+func isGone(err error) bool {
+	var httpErr *api.HTTPError
+	return errors.As(err, &httpErr) && httpErr.StatusCode == http.StatusGone
+}
+
+// isConflict reports whether err is a 409 response, which the commits
+// endpoint returns for empty repositories
+func isConflict(err error) bool {
+	var httpErr *api.HTTPError
+	return errors.As(err, &httpErr) && httpErr.StatusCode == http.StatusConflict
+}
+
+// isUnauthorized reports whether err is a 401 response, a bad or expired token
+func isUnauthorized(err error) bool {
+	var httpErr *api.HTTPError
+	return errors.As(err, &httpErr) && httpErr.StatusCode == http.StatusUnauthorized
+}
+
+// isForbidden reports whether err is a 403 response, usually a missing token scope
+func isForbidden(err error) bool {
+	var httpErr *api.HTTPError
+	return errors.As(err, &httpErr) && httpErr.StatusCode == http.StatusForbidden
+}
+
+// isAppScopeForbidden reports whether err is the 403 GitHub returns when a
+// GitHub App installation token lacks the permission an endpoint needs. The
+// message is the only thing telling it apart from a user without access.
+func isAppScopeForbidden(err error) bool {
+	var httpErr *api.HTTPError
+	return errors.As(err, &httpErr) && httpErr.StatusCode == http.StatusForbidden &&
+		strings.Contains(httpErr.Message, "Resource not accessible by integration")
+}
+
+func getRepositorySettings(client apiClient, owner, repo string, governance *GovernanceConfig) error {
 	var repoData struct {
+		Visibility          string `json:"visibility"`
 		Private             bool   `json:"private"`
 		Archived            bool   `json:"archived"`
 		Disabled            bool   `json:"disabled"`
@@ -17,10 +101,30 @@ func getRepositorySettings(client api.RESTClient, owner, repo string, governance
 		AllowRebaseMerge    bool   `json:"allow_rebase_merge"`
 		AllowAutoMerge      bool   `json:"allow_auto_merge"`
 		DeleteBranchOnMerge bool   `json:"delete_branch_on_merge"`
+		SquashMergeTitle    string `json:"squash_merge_commit_title"`
+		SquashMergeMessage  string `json:"squash_merge_commit_message"`
+		MergeCommitTitle    string `json:"merge_commit_title"`
+		MergeCommitMessage  string `json:"merge_commit_message"`
 		HasIssues           bool   `json:"has_issues"`
 		HasProjects         bool   `json:"has_projects"`
 		HasWiki             bool   `json:"has_wiki"`
 		HasDownloads        bool   `json:"has_downloads"`
+		CreatedAt           string `json:"created_at"`
+		UpdatedAt           string `json:"updated_at"`
+		PushedAt            string `json:"pushed_at"`
+		Size                int    `json:"size"`
+		OpenIssuesCount     int    `json:"open_issues_count"`
+		ForksCount          int    `json:"forks_count"`
+		StargazersCount     int    `json:"stargazers_count"`
+		SubscribersCount    int    `json:"subscribers_count"`
+		Language            string `json:"language"`
+		Fork                bool   `json:"fork"`
+		Parent              *struct {
+			FullName string `json:"full_name"`
+		} `json:"parent"`
+		Source *struct {
+			FullName string `json:"full_name"`
+		} `json:"source"`
 	}
 
 	err := client.Get(fmt.Sprintf("repos/%s/%s", owner, repo), &repoData)
@@ -29,74 +133,211 @@ func getRepositorySettings(client api.RESTClient, owner, repo string, governance
 	}
 
 	governance.RepoSettings = RepositorySettings{
-		Private:             repoData.Private,
-		Archived:            repoData.Archived,
-		Disabled:            repoData.Disabled,
-		DefaultBranch:       repoData.DefaultBranch,
-		AllowMergeCommit:    repoData.AllowMergeCommit,
-		AllowSquashMerge:    repoData.AllowSquashMerge,
-		AllowRebaseMerge:    repoData.AllowRebaseMerge,
-		AllowAutoMerge:      repoData.AllowAutoMerge,
-		DeleteBranchOnMerge: repoData.DeleteBranchOnMerge,
-		HasIssues:           repoData.HasIssues,
-		HasProjects:         repoData.HasProjects,
-		HasWiki:             repoData.HasWiki,
-		HasDownloads:        repoData.HasDownloads,
+		Visibility:               repoData.Visibility,
+		Private:                  repoData.Private,
+		Archived:                 repoData.Archived,
+		Disabled:                 repoData.Disabled,
+		DefaultBranch:            repoData.DefaultBranch,
+		AllowMergeCommit:         repoData.AllowMergeCommit,
+		AllowSquashMerge:         repoData.AllowSquashMerge,
+		AllowRebaseMerge:         repoData.AllowRebaseMerge,
+		AllowAutoMerge:           repoData.AllowAutoMerge,
+		DeleteBranchOnMerge:      repoData.DeleteBranchOnMerge,
+		HasIssues:                repoData.HasIssues,
+		SquashMergeCommitTitle:   allowedValue(repoData.AllowSquashMerge, repoData.SquashMergeTitle),
+		SquashMergeCommitMessage: allowedValue(repoData.AllowSquashMerge, repoData.SquashMergeMessage),
+		MergeCommitTitle:         allowedValue(repoData.AllowMergeCommit, repoData.MergeCommitTitle),
+		MergeCommitMessage:       allowedValue(repoData.AllowMergeCommit, repoData.MergeCommitMessage),
+		HasProjects:              repoData.HasProjects,
+		HasWiki:                  repoData.HasWiki,
+		HasDownloads:             repoData.HasDownloads,
+		CreatedAt:                repoData.CreatedAt,
+		UpdatedAt:                repoData.UpdatedAt,
+		PushedAt:                 repoData.PushedAt,
+		SizeKB:                   repoData.Size,
+		OpenIssues:               repoData.OpenIssuesCount,
+		Forks:                    repoData.ForksCount,
+		Stargazers:               repoData.StargazersCount,
+		Watchers:                 repoData.SubscribersCount,
+		Language:                 repoData.Language,
+		Fork:                     repoData.Fork,
+	}
+	if repoData.Parent != nil {
+		governance.RepoSettings.Parent = repoData.Parent.FullName
+	}
+	if repoData.Source != nil {
+		governance.RepoSettings.Source = repoData.Source.FullName
+	}
+	// size is also 0 for repositories GitHub hasn't measured yet, so it is
+	// confirmed by the commit list, which answers 409 when there are none
+	if repoData.Size == 0 {
+		var commits []struct{}
+		err = client.Get(fmt.Sprintf("repos/%s/%s/commits?per_page=1", owner, repo), &commits)
+		governance.RepoSettings.Empty = isConflict(err)
+	}
+
+	var topics struct {
+		Names []string `json:"names"`
+	}
+	err = client.Get(fmt.Sprintf("repos/%s/%s/topics", owner, repo), &topics)
+	if err != nil {
+		return err
+	}
+	governance.RepoSettings.Topics = topics.Names
+
+	// Custom properties are not available on older GHES versions
+	var properties []struct {
+		PropertyName string      `json:"property_name"`
+		Value        interface{} `json:"value"`
+	}
+	err = client.Get(fmt.Sprintf("repos/%s/%s/properties/values", owner, repo), &properties)
+	if err != nil {
+		if isNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	for _, property := range properties {
+		governance.RepoSettings.CustomProperties = append(governance.RepoSettings.CustomProperties, KeyValue{
+			Key:   property.PropertyName,
+			Value: propertyValueString(property.Value),
+		})
 	}
 
 	return nil
 }
 
-func getRulesets(client api.RESTClient, owner, repo string, governance *GovernanceConfig) error {
+// propertyValueString flattens a custom property value, which is a string,
+// a list of strings for multi-select properties, or null when unset
+func propertyValueString(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case []interface{}:
+		values := make([]string, 0, len(v))
+		for _, item := range v {
+			values = append(values, fmt.Sprint(item))
+		}
+		return strings.Join(values, ", ")
+	case nil:
+		return ""
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// rulesetResponse mirrors the fields we use from GET /repos/{owner}/{repo}/rulesets/{id}
+type rulesetResponse struct {
+	ID          int    `json:"id"`
+	Name        string `json:"name"`
+	Target      string `json:"target"`
+	Enforcement string `json:"enforcement"`
+	SourceType  string `json:"source_type"`
+	CreatedAt   string `json:"created_at"`
+	UpdatedAt   string `json:"updated_at"`
+	// Only returned to callers who can edit the ruleset
+	BypassActors []restBypassActor `json:"bypass_actors"`
+	Rules        []struct {
+		Type       string `json:"type"`
+		Parameters struct {
+			RequiredStatusChecks []struct {
+				Context string `json:"context"`
+			} `json:"required_status_checks,omitempty"`
+			RequiredApprovingReviewCount int  `json:"required_approving_review_count,omitempty"`
+			DismissStaleReviews          bool `json:"dismiss_stale_reviews,omitempty"`
+			RequireCodeOwnerReviews      bool `json:"require_code_owner_reviews,omitempty"`
+			// merge_queue parameters
+			MergeMethod       string `json:"merge_method,omitempty"`
+			MinEntriesToMerge int    `json:"min_entries_to_merge,omitempty"`
+			MaxEntriesToMerge int    `json:"max_entries_to_merge,omitempty"`
+		} `json:"parameters,omitempty"`
+	} `json:"rules"`
+	Conditions struct {
+		RefName struct {
+			Include []string `json:"include"`
+			Exclude []string `json:"exclude"`
+		} `json:"ref_name"`
+		// Only set on organization rulesets
+		RepositoryName struct {
+			Include []string `json:"include"`
+			Exclude []string `json:"exclude"`
+		} `json:"repository_name"`
+	} `json:"conditions"`
+}
+
+func getRulesets(client apiClient, owner, repo string, governance *GovernanceConfig) error {
 	// First try to get repository rulesets (newer API)
-	var rulesets struct {
-		Rulesets []struct {
-			ID     int    `json:"id"`
-			Name   string `json:"name"`
-			Target string `json:"target"`
-			Rules  []struct {
-				Type       string `json:"type"`
-				Parameters struct {
-					RequiredStatusChecks []struct {
-						Context string `json:"context"`
-					} `json:"required_status_checks,omitempty"`
-					RequiredApprovingReviewCount int  `json:"required_approving_review_count,omitempty"`
-					DismissStaleReviews          bool `json:"dismiss_stale_reviews,omitempty"`
-					RequireCodeOwnerReviews      bool `json:"require_code_owner_reviews,omitempty"`
-					RequireLinearHistory         bool `json:"require_linear_history,omitempty"`
-				} `json:"parameters,omitempty"`
-			} `json:"rules"`
-			Conditions struct {
-				RefName struct {
-					Include []string `json:"include"`
-					Exclude []string `json:"exclude"`
-				} `json:"ref_name"`
-			} `json:"conditions"`
-		} `json:"rulesets"`
-	}
-
-	err := client.Get(fmt.Sprintf("repos/%s/%s/rulesets", owner, repo), &rulesets)
+	var rulesetList []struct {
+		ID int `json:"id"`
+	}
+
+	err := getPaginated(client, fmt.Sprintf("repos/%s/%s/rulesets?includes_parents=true", owner, repo), func(page []struct {
+		ID int `json:"id"`
+	}) {
+		rulesetList = append(rulesetList, page...)
+	})
 	if err != nil {
 		// Fallback to branch protection if rulesets API fails
 		return getBranchProtection(client, owner, repo, governance)
 	}
 
+	// The list endpoint only returns summaries, so fetch each ruleset for its rules
+	var rulesets []rulesetResponse
+	seen := map[int]bool{}
+	for _, summary := range rulesetList {
+		var ruleset rulesetResponse
+		if err := client.Get(fmt.Sprintf("repos/%s/%s/rulesets/%d", owner, repo, summary.ID), &ruleset); err != nil {
+			// Skip if ruleset details are not accessible
+			continue
+		}
+		rulesets = append(rulesets, ruleset)
+		seen[ruleset.ID] = true
+	}
+
+	inherited, err := getInheritedRulesets(client, owner, repo, seen)
+	if err != nil {
+		return err
+	}
+	rulesets = append(rulesets, inherited...)
+
+	resolver := newBypassActorResolver(client, owner)
+
 	// Convert rulesets to our format
-	for _, ruleset := range rulesets.Rulesets {
+	for _, ruleset := range rulesets {
+		// Tag rulesets are reported by the tag-rules section
+		if ruleset.Target == "tag" {
+			continue
+		}
+
 		// Determine pattern from conditions
 		pattern := "*" // default
 		if len(ruleset.Conditions.RefName.Include) > 0 {
 			pattern = ruleset.Conditions.RefName.Include[0] // Use first include pattern
 		}
 
-		// Initialize ruleset with default values
+		// Initialize ruleset with default values. A ruleset without a
+		// non_fast_forward or deletion rule doesn't restrict either.
 		rulesetObj := Ruleset{
-			Name:    ruleset.Name,
-			Pattern: pattern,
+			Name:             ruleset.Name,
+			Pattern:          pattern,
+			Enforcement:      ruleset.Enforcement,
+			Source:           "repo",
+			RefNameInclude:   ruleset.Conditions.RefName.Include,
+			RefNameExclude:   ruleset.Conditions.RefName.Exclude,
+			BypassActors:     resolver.resolve(ruleset.BypassActors),
+			CreatedAt:        utils.NormalizeTimestamp(ruleset.CreatedAt),
+			UpdatedAt:        utils.NormalizeTimestamp(ruleset.UpdatedAt),
+			AllowForcePushes: true,
+			AllowDeletions:   true,
+		}
+
+		if ruleset.SourceType == "Organization" {
+			rulesetObj.Source = "organization"
 		}
 
 		// Process rules to extract settings
 		for _, rule := range ruleset.Rules {
+			rulesetObj.RuleTypes = append(rulesetObj.RuleTypes, rule.Type)
 			switch rule.Type {
 			case "required_status_checks":
 				for _, check := range rule.Parameters.RequiredStatusChecks {
@@ -108,13 +349,23 @@ func getRulesets(client api.RESTClient, owner, repo string, governance *Governan
 				rulesetObj.DismissStaleReviews = rule.Parameters.DismissStaleReviews
 				rulesetObj.RequireCodeOwnerReviews = rule.Parameters.RequireCodeOwnerReviews
 			case "required_linear_history":
-				rulesetObj.RequiredLinearHistory = rule.Parameters.RequireLinearHistory
-			case "force_push":
-				rulesetObj.AllowForcePushes = false // force_push rule means it's restricted
+				rulesetObj.RequiredLinearHistory = true
+			case "required_signatures":
+				rulesetObj.RequireSignedCommits = true
+			case "non_fast_forward":
+				rulesetObj.AllowForcePushes = false
 			case "deletion":
-				rulesetObj.AllowDeletions = false // deletion rule means it's restricted
+				rulesetObj.AllowDeletions = false
 			case "required_conversation_resolution":
 				rulesetObj.RequiredConversationResolution = true
+			case "merge_queue":
+				// Servers without merge queues never return this rule
+				rulesetObj.MergeQueueEnabled = true
+				rulesetObj.MergeQueue = &MergeQueue{
+					MergeMethod:       rule.Parameters.MergeMethod,
+					MinEntriesToMerge: rule.Parameters.MinEntriesToMerge,
+					MaxEntriesToMerge: rule.Parameters.MaxEntriesToMerge,
+				}
 			}
 		}
 
@@ -124,7 +375,66 @@ func getRulesets(client api.RESTClient, owner, repo string, governance *Governan
 	return nil
 }
 
-func getBranchProtection(client api.RESTClient, owner, repo string, governance *GovernanceConfig) error {
+// getInheritedRulesets returns the organization rulesets that apply to repo
+// but weren't already listed by the repository endpoint, which older servers
+// return without parents. User-owned repositories and tokens that can't read
+// organization rulesets yield nothing.
+func getInheritedRulesets(client apiClient, owner, repo string, seen map[int]bool) ([]rulesetResponse, error) {
+	var rulesetList []struct {
+		ID int `json:"id"`
+	}
+	err := getPaginated(client, fmt.Sprintf("orgs/%s/rulesets", owner), func(page []struct {
+		ID int `json:"id"`
+	}) {
+		rulesetList = append(rulesetList, page...)
+	})
+	if err != nil {
+		if isNotFound(err) || isForbidden(err) {
+			logger.Info(fmt.Sprintf("organization rulesets of %s are not accessible, skipping", owner), repoAttr(owner, repo))
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var rulesets []rulesetResponse
+	for _, summary := range rulesetList {
+		if seen[summary.ID] {
+			continue
+		}
+
+		var ruleset rulesetResponse
+		if err := client.Get(fmt.Sprintf("orgs/%s/rulesets/%d", owner, summary.ID), &ruleset); err != nil {
+			if isForbidden(err) || isNotFound(err) {
+				continue
+			}
+			return nil, err
+		}
+
+		conditions := ruleset.Conditions.RepositoryName
+		if !matchesAny(repo, conditions.Include) || matchesAny(repo, conditions.Exclude) {
+			continue
+		}
+		ruleset.SourceType = "Organization"
+		rulesets = append(rulesets, ruleset)
+	}
+	return rulesets, nil
+}
+
+// matchesAny reports whether name matches one of the fnmatch patterns of a
+// ruleset condition, where ~ALL matches everything
+func matchesAny(name string, patterns []string) bool {
+	for _, pattern := range patterns {
+		if pattern == "~ALL" {
+			return true
+		}
+		if matched, err := path.Match(pattern, name); err == nil && matched {
+			return true
+		}
+	}
+	return false
+}
+
+func getBranchProtection(client apiClient, owner, repo string, governance *GovernanceConfig) error {
 	// First, get all branches
 	var branches []struct {
 		Name      string `json:"name"`
@@ -155,11 +465,11 @@ func getBranchProtection(client api.RESTClient, owner, repo string, governance *
 				} `json:"checks"`
 			} `json:"required_status_checks"`
 			RequiredPullRequestReviews struct {
-				RequiredApprovingReviewCount   int  `json:"required_approving_review_count"`
-				DismissStaleReviews            bool `json:"dismiss_stale_reviews"`
-				RequireCodeOwnerReviews        bool `json:"require_code_owner_reviews"`
-				RequiredLinearHistory          bool `json:"required_linear_history"`
-				RequireConversationResolution  bool `json:"require_conversation_resolution"`
+				RequiredApprovingReviewCount  int  `json:"required_approving_review_count"`
+				DismissStaleReviews           bool `json:"dismiss_stale_reviews"`
+				RequireCodeOwnerReviews       bool `json:"require_code_owner_reviews"`
+				RequiredLinearHistory         bool `json:"required_linear_history"`
+				RequireConversationResolution bool `json:"require_conversation_resolution"`
 			} `json:"required_pull_request_reviews"`
 			Restrictions struct {
 				Users []struct {
@@ -175,6 +485,9 @@ func getBranchProtection(client api.RESTClient, owner, repo string, governance *
 			AllowDeletions struct {
 				Enabled bool `json:"enabled"`
 			} `json:"allow_deletions"`
+			RequiredSignatures struct {
+				Enabled bool `json:"enabled"`
+			} `json:"required_signatures"`
 		}
 
 		err := client.Get(fmt.Sprintf("repos/%s/%s/branches/%s/protection", owner, repo, branch.Name), &protection)
@@ -191,18 +504,22 @@ func getBranchProtection(client api.RESTClient, owner, repo string, governance *
 		}
 
 		ruleset := Ruleset{
-			Name:                            fmt.Sprintf("%s Branch Protection", branch.Name),
-			Pattern:                         branch.Name,
-			EnforceAdmins:                   protection.EnforceAdmins.Enabled,
-			RequiredStatusChecks:            requiredChecks,
-			RequiredPullRequestReviews:      protection.RequiredPullRequestReviews.RequiredApprovingReviewCount > 0,
-			RequiredApprovingReviewCount:    protection.RequiredPullRequestReviews.RequiredApprovingReviewCount,
-			DismissStaleReviews:             protection.RequiredPullRequestReviews.DismissStaleReviews,
-			RequireCodeOwnerReviews:         protection.RequiredPullRequestReviews.RequireCodeOwnerReviews,
-			RequiredLinearHistory:           protection.RequiredPullRequestReviews.RequiredLinearHistory,
-			AllowForcePushes:                protection.AllowForcePushes.Enabled,
-			AllowDeletions:                  protection.AllowDeletions.Enabled,
-			RequiredConversationResolution:  protection.RequiredPullRequestReviews.RequireConversationResolution,
+			Name:                           fmt.Sprintf("%s Branch Protection", branch.Name),
+			Pattern:                        branch.Name,
+			Enforcement:                    "active",
+			EnforceAdmins:                  protection.EnforceAdmins.Enabled,
+			RequiredStatusChecks:           requiredChecks,
+			RequiredPullRequestReviews:     protection.RequiredPullRequestReviews.RequiredApprovingReviewCount > 0,
+			RequiredApprovingReviewCount:   protection.RequiredPullRequestReviews.RequiredApprovingReviewCount,
+			DismissStaleReviews:            protection.RequiredPullRequestReviews.DismissStaleReviews,
+			RequireCodeOwnerReviews:        protection.RequiredPullRequestReviews.RequireCodeOwnerReviews,
+			RequiredLinearHistory:          protection.RequiredPullRequestReviews.RequiredLinearHistory,
+			RequireSignedCommits:           protection.RequiredSignatures.Enabled,
+			AllowForcePushes:               protection.AllowForcePushes.Enabled,
+			AllowDeletions:                 protection.AllowDeletions.Enabled,
+			RequiredConversationResolution: protection.RequiredPullRequestReviews.RequireConversationResolution,
+			// The protection response doesn't report merge queues, so
+			// MergeQueueEnabled stays false for classic protection
 		}
 
 		governance.Rulesets = append(governance.Rulesets, ruleset)
@@ -212,8 +529,16 @@ func getBranchProtection(client api.RESTClient, owner, repo string, governance *
 	return nil
 }
 
-func getCollaborators(client api.RESTClient, owner, repo string, governance *GovernanceConfig) error {
-	var collaborators []struct {
+// allowedValue returns value when the setting it belongs to is enabled
+func allowedValue(enabled bool, value string) string {
+	if !enabled {
+		return ""
+	}
+	return value
+}
+
+func getCollaborators(client apiClient, owner, repo string, governance *GovernanceConfig) error {
+	type collaboratorResponse struct {
 		Login       string `json:"login"`
 		Type        string `json:"type"`
 		Permissions struct {
@@ -225,7 +550,10 @@ func getCollaborators(client api.RESTClient, owner, repo string, governance *Gov
 		} `json:"permissions"`
 	}
 
-	err := client.Get(fmt.Sprintf("repos/%s/%s/collaborators", owner, repo), &collaborators)
+	var collaborators []collaboratorResponse
+	err := getPaginated(client, fmt.Sprintf("repos/%s/%s/collaborators", owner, repo), func(page []collaboratorResponse) {
+		collaborators = append(collaborators, page...)
+	})
 	if err != nil {
 		return err
 	}
@@ -249,17 +577,28 @@ func getCollaborators(client api.RESTClient, owner, repo string, governance *Gov
 		})
 	}
 
+	if resolvePermissions {
+		if err := resolveCollaboratorSources(client, owner, repo, governance.Collaborators); err != nil {
+			return err
+		}
+	}
+	if withActivity {
+		return resolveCollaboratorActivity(client, owner, repo, governance.Collaborators)
+	}
 	return nil
 }
 
-func getTeams(client api.RESTClient, owner, repo string, governance *GovernanceConfig) error {
-	var teams []struct {
+func getTeams(client apiClient, owner, repo string, governance *GovernanceConfig) error {
+	type teamResponse struct {
 		Name       string `json:"name"`
 		Slug       string `json:"slug"`
 		Permission string `json:"permission"`
 	}
 
-	err := client.Get(fmt.Sprintf("repos/%s/%s/teams", owner, repo), &teams)
+	var teams []teamResponse
+	err := getPaginated(client, fmt.Sprintf("repos/%s/%s/teams", owner, repo), func(page []teamResponse) {
+		teams = append(teams, page...)
+	})
 	if err != nil {
 		return err
 	}
@@ -275,13 +614,16 @@ func getTeams(client api.RESTClient, owner, repo string, governance *GovernanceC
 	return nil
 }
 
-func getSecuritySettings(client api.RESTClient, owner, repo string, governance *GovernanceConfig) error {
-	// Get vulnerability alerts
-	var vulnAlerts struct {
-		Enabled bool `json:"enabled"`
+func getSecuritySettings(client apiClient, owner, repo string, governance *GovernanceConfig) error {
+	sources := map[string]string{}
+
+	// Get vulnerability alerts (204 when enabled, 404 when disabled)
+	err := client.Get(fmt.Sprintf("repos/%s/%s/vulnerability-alerts", owner, repo), nil)
+	vulnAlertsEnabled := err == nil
+	sources["vulnerability_alerts"] = sourceUnknown
+	if err == nil || isNotFound(err) {
+		sources["vulnerability_alerts"] = sourceRepo
 	}
-	err := client.Get(fmt.Sprintf("repos/%s/%s/vulnerability-alerts", owner, repo), &vulnAlerts)
-	vulnAlertsEnabled := err == nil && vulnAlerts.Enabled
 
 	// Get automated security fixes
 	var autoFixes struct {
@@ -289,28 +631,142 @@ func getSecuritySettings(client api.RESTClient, owner, repo string, governance *
 	}
 	err = client.Get(fmt.Sprintf("repos/%s/%s/automated-security-fixes", owner, repo), &autoFixes)
 	autoFixesEnabled := err == nil && autoFixes.Enabled
+	sources["automated_security_fixes"] = sourceUnknown
+	if err == nil {
+		sources["automated_security_fixes"] = sourceRepo
+	}
+
+	// Secret scanning status is reported on the repository itself for admins
+	var repoData struct {
+		SecurityAndAnalysis *struct {
+			SecretScanning struct {
+				Status string `json:"status"`
+			} `json:"secret_scanning"`
+			SecretScanningPushProtection struct {
+				Status string `json:"status"`
+			} `json:"secret_scanning_push_protection"`
+		} `json:"security_and_analysis"`
+	}
+	err = client.Get(fmt.Sprintf("repos/%s/%s", owner, repo), &repoData)
+	if err != nil {
+		return err
+	}
+
+	security := SecuritySettings{
+		VulnerabilityAlerts:    vulnAlertsEnabled,
+		AutomatedSecurityFixes: autoFixesEnabled,
+		DependencyGraphEnabled: true, // Usually enabled by default
+		Sources:                sources,
+	}
+	// security_and_analysis is only returned to admins
+	sources["secret_scanning"] = sourceUnknown
+	sources["secret_scanning_push_protection"] = sourceUnknown
+	if analysis := repoData.SecurityAndAnalysis; analysis != nil {
+		security.SecretScanning = analysis.SecretScanning.Status == "enabled"
+		security.SecretScanningPushProtection = analysis.SecretScanningPushProtection.Status == "enabled"
+		sources["secret_scanning"] = sourceRepo
+		sources["secret_scanning_push_protection"] = sourceRepo
+	}
+	sources["dependency_graph_enabled"] = sourceUnknown
 
-	// Get secret scanning status (requires GraphQL or specific REST endpoint)
-	// For now, we'll set default values since these endpoints may require special permissions
-	governance.SecuritySettings = SecuritySettings{
-		VulnerabilityAlerts:          vulnAlertsEnabled,
-		AutomatedSecurityFixes:       autoFixesEnabled,
-		SecretScanning:               false, // Would need GraphQL or enterprise endpoint
-		SecretScanningPushProtection: false, // Would need GraphQL or enterprise endpoint
-		DependencyGraphEnabled:        true,  // Usually enabled by default
+	applyOrgSecurityConfiguration(client, owner, repo, &security)
+
+	if withAlerts {
+		security.SecretScanningAlertCount = countOpenAlerts(client, owner, repo, "secret-scanning", "secret scanning")
+		security.DependabotAlertCount = countOpenAlerts(client, owner, repo, "dependabot", "Dependabot")
 	}
+	governance.SecuritySettings = security
 
 	return nil
 }
 
-func getLabels(client api.RESTClient, owner, repo string, governance *GovernanceConfig) error {
-	var labels []struct {
+// countOpenAlerts counts the open alerts of a repository's kind of alerts
+// with a single request of one alert per page: the page number of the Link
+// header's last page is then the count, and without a Link header the one
+// page holds all of them. Tokens without access and repositories with the
+// feature off answer 403 or 404, which yields -1 for unknown. Other failures,
+// and cursor-paginated links without a last page, are logged and yield -1 as
+// well, so a count never costs the rest of the security settings.
+func countOpenAlerts(client apiClient, owner, repo, kind, label string) *int {
+	count := -1
+	var alerts []struct {
+		Number int `json:"number"`
+	}
+	headers, err := responseHeaders(client, fmt.Sprintf("repos/%s/%s/%s/alerts?state=open&per_page=1", owner, repo, kind), &alerts)
+	switch {
+	case isForbidden(err) || isNotFound(err):
+		logger.Info(fmt.Sprintf("%s alerts of %s/%s are not accessible or disabled (%v)", label, owner, repo, err), repoAttr(owner, repo))
+	case err != nil:
+		logger.Warn(fmt.Sprintf("failed to count the %s alerts of %s/%s: %v", label, owner, repo, err), repoAttr(owner, repo))
+	case headers.Get("Link") == "":
+		count = len(alerts)
+	default:
+		if last, ok := utils.LastPage(headers.Get("Link")); ok {
+			count = last
+		} else {
+			logger.Warn(fmt.Sprintf("failed to count the %s alerts of %s/%s: the Link header has no last page", label, owner, repo), repoAttr(owner, repo))
+		}
+	}
+	return &count
+}
+
+// applyOrgSecurityConfiguration overrides the settings an enforced
+// organization code security configuration controls. Repositories can't
+// change those settings, so the configuration is the authoritative source
+// even when the repository endpoints are inconclusive for the caller.
+func applyOrgSecurityConfiguration(client apiClient, owner, repo string, security *SecuritySettings) {
+	var attached struct {
+		Configuration *struct {
+			Name                         string `json:"name"`
+			Enforcement                  string `json:"enforcement"`
+			DependencyGraph              string `json:"dependency_graph"`
+			DependabotAlerts             string `json:"dependabot_alerts"`
+			DependabotSecurityUpdates    string `json:"dependabot_security_updates"`
+			SecretScanning               string `json:"secret_scanning"`
+			SecretScanningPushProtection string `json:"secret_scanning_push_protection"`
+		} `json:"configuration"`
+	}
+	// User repositories and callers without admin access get 404/403
+	err := client.Get(fmt.Sprintf("repos/%s/%s/code-security-configuration", owner, repo), &attached)
+	if err != nil {
+		if !isNotFound(err) {
+			logger.Warn(fmt.Sprintf("could not read the code security configuration for %s/%s: %v", owner, repo, err), repoAttr(owner, repo))
+		}
+		return
+	}
+	config := attached.Configuration
+	if config == nil || config.Enforcement != "enforced" {
+		return
+	}
+
+	enforce := func(key, state string, value *bool) {
+		// not_set leaves the setting to the repository
+		if state != "enabled" && state != "disabled" {
+			return
+		}
+		*value = state == "enabled"
+		security.Sources[key] = sourceOrgEnforced
+	}
+	enforce("vulnerability_alerts", config.DependabotAlerts, &security.VulnerabilityAlerts)
+	enforce("automated_security_fixes", config.DependabotSecurityUpdates, &security.AutomatedSecurityFixes)
+	enforce("secret_scanning", config.SecretScanning, &security.SecretScanning)
+	enforce("secret_scanning_push_protection", config.SecretScanningPushProtection, &security.SecretScanningPushProtection)
+	enforce("dependency_graph_enabled", config.DependencyGraph, &security.DependencyGraphEnabled)
+
+	logger.Info(fmt.Sprintf("%s/%s has the enforced code security configuration %q", owner, repo, config.Name), repoAttr(owner, repo))
+}
+
+func getLabels(client apiClient, owner, repo string, governance *GovernanceConfig) error {
+	type labelResponse struct {
 		Name        string `json:"name"`
 		Color       string `json:"color"`
 		Description string `json:"description"`
 	}
 
-	err := client.Get(fmt.Sprintf("repos/%s/%s/labels", owner, repo), &labels)
+	var labels []labelResponse
+	err := getPaginated(client, fmt.Sprintf("repos/%s/%s/labels", owner, repo), func(page []labelResponse) {
+		labels = append(labels, page...)
+	})
 	if err != nil {
 		return err
 	}
@@ -326,27 +782,1131 @@ func getLabels(client api.RESTClient, owner, repo string, governance *Governance
 	return nil
 }
 
-func getMilestones(client api.RESTClient, owner, repo string, governance *GovernanceConfig) error {
-	var milestones []struct {
+func getMilestones(client apiClient, owner, repo string, governance *GovernanceConfig) error {
+	type milestoneResponse struct {
 		Title       string `json:"title"`
 		Description string `json:"description"`
 		State       string `json:"state"`
 		DueOn       string `json:"due_on"`
+		UpdatedAt   string `json:"updated_at"`
 	}
 
-	err := client.Get(fmt.Sprintf("repos/%s/%s/milestones?state=all", owner, repo), &milestones)
+	var milestones []milestoneResponse
+	err := getPaginated(client, fmt.Sprintf("repos/%s/%s/milestones?state=all", owner, repo), func(page []milestoneResponse) {
+		milestones = append(milestones, page...)
+	})
 	if err != nil {
 		return err
 	}
 
 	for _, milestone := range milestones {
+		// The milestones endpoint has no since parameter, so --since filters here
+		if !sinceTime.IsZero() {
+			updatedAt, err := time.Parse(time.RFC3339, milestone.UpdatedAt)
+			if err == nil && updatedAt.Before(sinceTime) {
+				continue
+			}
+		}
+
 		governance.Milestones = append(governance.Milestones, Milestone{
 			Title:       milestone.Title,
 			Description: milestone.Description,
 			State:       milestone.State,
 			DueOn:       milestone.DueOn,
+			UpdatedAt:   milestone.UpdatedAt,
+		})
+	}
+
+	return nil
+}
+
+func getWebhooks(client apiClient, owner, repo string, governance *GovernanceConfig) error {
+	var hooks []struct {
+		ID     int      `json:"id"`
+		Active bool     `json:"active"`
+		Events []string `json:"events"`
+		Config struct {
+			URL         string `json:"url"`
+			ContentType string `json:"content_type"`
+			Secret      string `json:"secret"`
+		} `json:"config"`
+	}
+
+	err := client.Get(fmt.Sprintf("repos/%s/%s/hooks", owner, repo), &hooks)
+	if err != nil {
+		return err
+	}
+
+	for _, hook := range hooks {
+		// The API only returns a masked secret; keep just whether one is set
+		governance.Webhooks = append(governance.Webhooks, Webhook{
+			ID:          hook.ID,
+			URL:         hook.Config.URL,
+			Events:      hook.Events,
+			Active:      hook.Active,
+			ContentType: hook.Config.ContentType,
+			Secret:      hook.Config.Secret != "",
+		})
+	}
+
+	return nil
+}
+
+func getEnvironments(client apiClient, owner, repo string, governance *GovernanceConfig) error {
+	var response struct {
+		Environments []struct {
+			Name            string `json:"name"`
+			ProtectionRules []struct {
+				Type      string `json:"type"`
+				WaitTimer int    `json:"wait_timer"`
+				Reviewers []struct {
+					Type     string `json:"type"`
+					Reviewer struct {
+						Login string `json:"login"`
+						Slug  string `json:"slug"`
+					} `json:"reviewer"`
+				} `json:"reviewers"`
+			} `json:"protection_rules"`
+			DeploymentBranchPolicy *struct {
+				ProtectedBranches    bool `json:"protected_branches"`
+				CustomBranchPolicies bool `json:"custom_branch_policies"`
+			} `json:"deployment_branch_policy"`
+		} `json:"environments"`
+	}
+
+	err := client.Get(fmt.Sprintf("repos/%s/%s/environments", owner, repo), &response)
+	if err != nil {
+		return err
+	}
+
+	for _, env := range response.Environments {
+		environment := Environment{
+			Name:                   env.Name,
+			DeploymentBranchPolicy: "all",
+		}
+
+		for _, rule := range env.ProtectionRules {
+			switch rule.Type {
+			case "wait_timer":
+				environment.WaitTimer = rule.WaitTimer
+			case "required_reviewers":
+				for _, reviewer := range rule.Reviewers {
+					if reviewer.Type == "Team" {
+						environment.Reviewers = append(environment.Reviewers, "@"+reviewer.Reviewer.Slug)
+					} else {
+						environment.Reviewers = append(environment.Reviewers, reviewer.Reviewer.Login)
+					}
+				}
+			}
+		}
+
+		// A null policy means any branch can deploy
+		if policy := env.DeploymentBranchPolicy; policy != nil {
+			if policy.ProtectedBranches {
+				environment.DeploymentBranchPolicy = "protected"
+			} else if policy.CustomBranchPolicies {
+				environment.DeploymentBranchPolicy = "custom"
+			}
+		}
+
+		governance.Environments = append(governance.Environments, environment)
+	}
+
+	return nil
+}
+
+func getProtectedBranches(client apiClient, owner, repo string, governance *GovernanceConfig) error {
+	var branches []struct {
+		Name string `json:"name"`
+	}
+
+	err := client.Get(fmt.Sprintf("repos/%s/%s/branches?protected=true", owner, repo), &branches)
+	if err != nil {
+		return err
+	}
+
+	for _, branch := range branches {
+		protectedBranch := ProtectedBranch{Name: branch.Name}
+
+		var protection struct {
+			RequiredPullRequestReviews *struct {
+				RequiredApprovingReviewCount int `json:"required_approving_review_count"`
+			} `json:"required_pull_request_reviews"`
+			EnforceAdmins *struct {
+				Enabled bool `json:"enabled"`
+			} `json:"enforce_admins"`
+			RequiredSignatures *struct {
+				Enabled bool `json:"enabled"`
+			} `json:"required_signatures"`
+			Restrictions *struct {
+				Users []struct {
+					Login string `json:"login"`
+				} `json:"users"`
+				Teams []struct {
+					Slug string `json:"slug"`
+				} `json:"teams"`
+				Apps []struct {
+					Slug string `json:"slug"`
+				} `json:"apps"`
+			} `json:"restrictions"`
+		}
+
+		path := fmt.Sprintf("repos/%s/%s/branches/%s/protection", owner, repo, url.PathEscape(branch.Name))
+		err := client.Get(path, &protection)
+		if err != nil {
+			// Branches protected only by rulesets report 404 for classic protection
+			if isNotFound(err) {
+				governance.ProtectedBranches = append(governance.ProtectedBranches, protectedBranch)
+				continue
+			}
+			return err
+		}
+
+		if protection.RequiredPullRequestReviews != nil {
+			protectedBranch.RequiredReviews = protection.RequiredPullRequestReviews.RequiredApprovingReviewCount
+		}
+		if protection.EnforceAdmins != nil {
+			protectedBranch.EnforceAdmins = protection.EnforceAdmins.Enabled
+		}
+
+		// Older API versions omit required_signatures from the protection
+		// payload; the dedicated endpoint returns 404 when it is not enabled
+		if protection.RequiredSignatures != nil {
+			protectedBranch.RequireSignedCommits = protection.RequiredSignatures.Enabled
+		} else {
+			var signatures struct {
+				Enabled bool `json:"enabled"`
+			}
+			err := client.Get(path+"/required_signatures", &signatures)
+			if err != nil && !isNotFound(err) {
+				return err
+			}
+			protectedBranch.RequireSignedCommits = err == nil && signatures.Enabled
+		}
+
+		if protection.Restrictions != nil {
+			for _, user := range protection.Restrictions.Users {
+				protectedBranch.Restrictions = append(protectedBranch.Restrictions, user.Login)
+			}
+			for _, team := range protection.Restrictions.Teams {
+				protectedBranch.Restrictions = append(protectedBranch.Restrictions, "@"+team.Slug)
+			}
+			for _, app := range protection.Restrictions.Apps {
+				protectedBranch.Restrictions = append(protectedBranch.Restrictions, "app:"+app.Slug)
+			}
+		}
+
+		governance.ProtectedBranches = append(governance.ProtectedBranches, protectedBranch)
+	}
+
+	return nil
+}
+
+func getDeployKeys(client apiClient, owner, repo string, governance *GovernanceConfig) error {
+	var keys []struct {
+		ID        int    `json:"id"`
+		Title     string `json:"title"`
+		ReadOnly  bool   `json:"read_only"`
+		CreatedAt string `json:"created_at"`
+		LastUsed  string `json:"last_used"`
+	}
+
+	err := client.Get(fmt.Sprintf("repos/%s/%s/keys", owner, repo), &keys)
+	if err != nil {
+		return err
+	}
+
+	// The public key material is deliberately not decoded
+	for _, key := range keys {
+		governance.DeployKeys = append(governance.DeployKeys, DeployKey{
+			ID:        key.ID,
+			Title:     key.Title,
+			ReadOnly:  key.ReadOnly,
+			CreatedAt: key.CreatedAt,
+			LastUsed:  key.LastUsed,
 		})
 	}
 
+	logger.Info(fmt.Sprintf("Found %d deploy key(s)", len(keys)), repoAttr(owner, repo))
+
+	return nil
+}
+
+func getActionsConfig(client apiClient, owner, repo string, governance *GovernanceConfig) error {
+	actions := &ActionsConfig{}
+
+	// Secret values are never returned by the API, only their names
+	var secrets struct {
+		Secrets []struct {
+			Name string `json:"name"`
+		} `json:"secrets"`
+	}
+	err := client.Get(fmt.Sprintf("repos/%s/%s/actions/secrets", owner, repo), &secrets)
+	switch {
+	case isForbidden(err):
+		logger.Info("token lacks permission to list Actions secrets (403)", repoAttr(owner, repo))
+	case err != nil:
+		return err
+	default:
+		for _, secret := range secrets.Secrets {
+			actions.SecretNames = append(actions.SecretNames, secret.Name)
+		}
+	}
+
+	var variables struct {
+		Variables []struct {
+			Name  string `json:"name"`
+			Value string `json:"value"`
+		} `json:"variables"`
+	}
+	err = client.Get(fmt.Sprintf("repos/%s/%s/actions/variables", owner, repo), &variables)
+	switch {
+	case isForbidden(err):
+		logger.Info("token lacks permission to list Actions variables (403)", repoAttr(owner, repo))
+	case err != nil:
+		return err
+	default:
+		for _, variable := range variables.Variables {
+			actions.Variables = append(actions.Variables, KeyValue{Key: variable.Name, Value: variable.Value})
+		}
+	}
+
+	governance.Actions = actions
 	return nil
-}
\ No newline at end of file
+}
+
+// codeOwnersPaths are the locations GitHub checks for CODEOWNERS, in order of precedence
+var codeOwnersPaths = []string{".github/CODEOWNERS", "CODEOWNERS", "docs/CODEOWNERS"}
+
+func getCodeOwners(client apiClient, owner, repo string, governance *GovernanceConfig) error {
+	codeOwners := &CodeOwners{}
+	governance.CodeOwners = codeOwners
+
+	// Without a ref the contents API reads from the default branch
+	var file struct {
+		Content  string `json:"content"`
+		Encoding string `json:"encoding"`
+	}
+	for _, path := range codeOwnersPaths {
+		err := client.Get(fmt.Sprintf("repos/%s/%s/contents/%s", owner, repo, path), &file)
+		if isNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		codeOwners.Exists = true
+		codeOwners.Path = path
+		break
+	}
+
+	if !codeOwners.Exists {
+		return nil
+	}
+
+	content, err := base64.StdEncoding.DecodeString(strings.ReplaceAll(file.Content, "\n", ""))
+	if err != nil {
+		return fmt.Errorf("failed to decode %s: %v", codeOwners.Path, err)
+	}
+
+	for _, line := range strings.Split(string(content), "\n") {
+		pattern, owners, ok := utils.ParseCodeOwnersLine(line)
+		if !ok {
+			continue
+		}
+		codeOwners.Entries = append(codeOwners.Entries, CodeOwnerRule{Pattern: pattern, Owners: owners})
+	}
+	codeOwners.RuleCount = len(codeOwners.Entries)
+
+	// GitHub reports unknown users/teams and syntax problems for us
+	var validation struct {
+		Errors []struct {
+			Line    int    `json:"line"`
+			Message string `json:"message"`
+		} `json:"errors"`
+	}
+	err = client.Get(fmt.Sprintf("repos/%s/%s/codeowners/errors", owner, repo), &validation)
+	if err != nil {
+		return err
+	}
+	for _, validationErr := range validation.Errors {
+		message := strings.TrimSpace(strings.Split(validationErr.Message, "\n")[0])
+		codeOwners.Errors = append(codeOwners.Errors, fmt.Sprintf("line %d: %s", validationErr.Line, message))
+	}
+
+	return nil
+}
+
+func getPages(client apiClient, owner, repo string, governance *GovernanceConfig) error {
+	var response struct {
+		CNAME  string `json:"cname"`
+		Public bool   `json:"public"`
+		Source struct {
+			Branch string `json:"branch"`
+			Path   string `json:"path"`
+		} `json:"source"`
+		HTTPSEnforced bool `json:"https_enforced"`
+	}
+
+	err := client.Get(fmt.Sprintf("repos/%s/%s/pages", owner, repo), &response)
+	if isNotFound(err) {
+		// Pages is simply not enabled
+		governance.Pages = &PagesConfig{}
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	governance.Pages = &PagesConfig{
+		Enabled:       true,
+		SourceBranch:  response.Source.Branch,
+		SourcePath:    response.Source.Path,
+		CustomDomain:  response.CNAME,
+		HTTPSEnforced: response.HTTPSEnforced,
+		Public:        response.Public,
+	}
+	return nil
+}
+
+func getAutolinks(client apiClient, owner, repo string, governance *GovernanceConfig) error {
+	var autolinks []Autolink
+	err := client.Get(fmt.Sprintf("repos/%s/%s/autolinks", owner, repo), &autolinks)
+	if isForbidden(err) {
+		logger.Info("token lacks admin permission to list autolinks (403)", repoAttr(owner, repo))
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	governance.Autolinks = autolinks
+	return nil
+}
+
+func getWorkflows(client apiClient, owner, repo string, governance *GovernanceConfig) error {
+	workflows := &WorkflowsConfig{}
+	governance.Workflows = workflows
+
+	var permissions struct {
+		Enabled bool `json:"enabled"`
+	}
+	err := client.Get(fmt.Sprintf("repos/%s/%s/actions/permissions", owner, repo), &permissions)
+	switch {
+	case isForbidden(err):
+		logger.Info("token lacks admin permission to read Actions permissions (403)", repoAttr(owner, repo))
+	case err != nil:
+		return err
+	default:
+		workflows.ActionsEnabled = &permissions.Enabled
+	}
+
+	// Workflow files are meaningless while Actions is off, so an empty list
+	// here would wrongly read as "no workflows"
+	if workflows.ActionsEnabled != nil && !*workflows.ActionsEnabled {
+		return nil
+	}
+
+	for page := 1; ; page++ {
+		var response struct {
+			Workflows []Workflow `json:"workflows"`
+		}
+
+		err := client.Get(fmt.Sprintf("repos/%s/%s/actions/workflows?per_page=%d&page=%d", owner, repo, perPage, page), &response)
+		if err != nil {
+			return err
+		}
+
+		workflows.Workflows = append(workflows.Workflows, response.Workflows...)
+
+		if len(response.Workflows) < perPage {
+			return nil
+		}
+	}
+}
+
+// dependabotPaths are the accepted locations of the Dependabot configuration
+var dependabotPaths = []string{".github/dependabot.yml", ".github/dependabot.yaml"}
+
+func getDependabot(client apiClient, owner, repo string, governance *GovernanceConfig) error {
+	dependabot := &Dependabot{}
+	governance.Dependabot = dependabot
+
+	var file struct {
+		Content string `json:"content"`
+	}
+	for _, path := range dependabotPaths {
+		err := client.Get(fmt.Sprintf("repos/%s/%s/contents/%s", owner, repo, path), &file)
+		if isNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		dependabot.Exists = true
+		dependabot.Path = path
+		break
+	}
+
+	if !dependabot.Exists {
+		return nil
+	}
+
+	content, err := base64.StdEncoding.DecodeString(strings.ReplaceAll(file.Content, "\n", ""))
+	if err != nil {
+		return fmt.Errorf("failed to decode %s: %v", dependabot.Path, err)
+	}
+
+	var config struct {
+		Updates []struct {
+			PackageEcosystem string `yaml:"package-ecosystem"`
+			Directory        string `yaml:"directory"`
+			Schedule         struct {
+				Interval string `yaml:"interval"`
+			} `yaml:"schedule"`
+		} `yaml:"updates"`
+	}
+	if err := yaml.Unmarshal(content, &config); err != nil {
+		// The file still exists, it just won't do anything useful
+		logger.Warn(fmt.Sprintf("failed to parse %s: %v", dependabot.Path, err), repoAttr(owner, repo))
+		return nil
+	}
+
+	for _, update := range config.Updates {
+		dependabot.Ecosystems = append(dependabot.Ecosystems, update.PackageEcosystem)
+		dependabot.Schedules = append(dependabot.Schedules, update.Schedule.Interval)
+	}
+
+	return nil
+}
+
+func getReleases(client apiClient, owner, repo string, governance *GovernanceConfig) error {
+	// Releases are returned newest first, so stop once the limit is reached
+	pageSize := perPage
+	if releaseLimit < pageSize {
+		pageSize = releaseLimit
+	}
+	for page := 1; len(governance.Releases) < releaseLimit; page++ {
+		var releases []Release
+		err := client.Get(fmt.Sprintf("repos/%s/%s/releases?per_page=%d&page=%d", owner, repo, pageSize, page), &releases)
+		if err != nil {
+			return err
+		}
+
+		for _, release := range releases {
+			if len(governance.Releases) >= releaseLimit {
+				break
+			}
+			governance.Releases = append(governance.Releases, release)
+		}
+
+		if len(releases) < pageSize {
+			break
+		}
+	}
+
+	var protections []struct {
+		Pattern string `json:"pattern"`
+	}
+	err := client.Get(fmt.Sprintf("repos/%s/%s/tags/protection", owner, repo), &protections)
+	switch {
+	case isNotFound(err), isGone(err):
+		// Tag protection was retired in favour of tag rulesets
+		logger.Info(fmt.Sprintf("tag protection is not available for %s/%s; check tag rulesets instead", owner, repo), repoAttr(owner, repo))
+	case isForbidden(err):
+		logger.Info("token lacks admin permission to list tag protection (403)", repoAttr(owner, repo))
+	case err != nil:
+		return err
+	default:
+		for _, protection := range protections {
+			governance.TagProtections = append(governance.TagProtections, TagProtection{Pattern: protection.Pattern})
+		}
+	}
+
+	return nil
+}
+
+// templateDirs are the directories GitHub searches for issue and PR
+// templates; "" is the repository root
+var templateDirs = []string{".github", "", "docs"}
+
+type contentEntry struct {
+	Name string `json:"name"`
+	Path string `json:"path"`
+	Type string `json:"type"`
+}
+
+// listContents lists a directory through the contents API, returning nil for
+// a directory that doesn't exist
+func listContents(client apiClient, owner, repo, path string) ([]contentEntry, error) {
+	var entries []contentEntry
+	err := client.Get(fmt.Sprintf("repos/%s/%s/contents/%s", owner, repo, path), &entries)
+	if isNotFound(err) {
+		return nil, nil
+	}
+	return entries, err
+}
+
+func getTemplates(client apiClient, owner, repo string, governance *GovernanceConfig) error {
+	templates := &Templates{}
+	governance.Templates = templates
+
+	// GitHub matches template names case-insensitively
+	for _, dir := range templateDirs {
+		entries, err := listContents(client, owner, repo, dir)
+		if err != nil {
+			return err
+		}
+
+		for _, entry := range entries {
+			name := strings.ToLower(entry.Name)
+			switch {
+			case name == "pull_request_template.md" || (name == "pull_request_template" && entry.Type == "dir"):
+				templates.HasPRTemplate = true
+			case name == "issue_template.md":
+				// Legacy single issue template
+				templates.IssueTemplates = append(templates.IssueTemplates, entry.Path)
+			case name == "issue_template" && entry.Type == "dir":
+				forms, err := listContents(client, owner, repo, entry.Path)
+				if err != nil {
+					return err
+				}
+				for _, form := range forms {
+					formName := strings.ToLower(form.Name)
+					switch {
+					case formName == "config.yml" || formName == "config.yaml":
+						templates.HasConfigYML = true
+					case form.Type == "file" && (strings.HasSuffix(formName, ".md") || strings.HasSuffix(formName, ".yml") || strings.HasSuffix(formName, ".yaml")):
+						templates.IssueTemplates = append(templates.IssueTemplates, form.Path)
+					}
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// getDefaultBranch looks up the repository's default branch for fetchers
+// that need it. Each fetcher only receives its own partial config, so the
+// branch the settings section found isn't available to it.
+func getDefaultBranch(client apiClient, owner, repo string) (string, error) {
+	var repository struct {
+		DefaultBranch string `json:"default_branch"`
+	}
+	if err := client.Get(fmt.Sprintf("repos/%s/%s", owner, repo), &repository); err != nil {
+		return "", err
+	}
+	return repository.DefaultBranch, nil
+}
+
+func getBranches(client apiClient, owner, repo string, governance *GovernanceConfig) error {
+	defaultBranch, err := getDefaultBranch(client, owner, repo)
+	if err != nil {
+		return err
+	}
+
+	type branchResponse struct {
+		Name      string `json:"name"`
+		Protected bool   `json:"protected"`
+	}
+
+	var branches []branchResponse
+	err = getPaginated(client, fmt.Sprintf("repos/%s/%s/branches", owner, repo), func(page []branchResponse) {
+		branches = append(branches, page...)
+	})
+	if err != nil {
+		return err
+	}
+
+	staleBefore := time.Now().AddDate(0, 0, -staleDays)
+	for _, branch := range branches {
+		result := Branch{Name: branch.Name, Protected: branch.Protected}
+
+		if branch.Name != defaultBranch {
+			var comparison struct {
+				AheadBy  int `json:"ahead_by"`
+				BehindBy int `json:"behind_by"`
+			}
+			// Only the counts are needed, so keep the commit list short
+			path := fmt.Sprintf("repos/%s/%s/compare/%s...%s?per_page=1", owner, repo, url.PathEscape(defaultBranch), url.PathEscape(branch.Name))
+			if err := client.Get(path, &comparison); err != nil {
+				return err
+			}
+			result.AheadBy = comparison.AheadBy
+			result.BehindBy = comparison.BehindBy
+		}
+
+		var commits []struct {
+			Commit struct {
+				Committer struct {
+					Date string `json:"date"`
+				} `json:"committer"`
+			} `json:"commit"`
+		}
+		err := client.Get(fmt.Sprintf("repos/%s/%s/commits?sha=%s&per_page=1", owner, repo, url.QueryEscape(branch.Name)), &commits)
+		if err != nil {
+			return err
+		}
+		if len(commits) > 0 {
+			result.LastCommitDate = commits[0].Commit.Committer.Date
+			if staleDays > 0 {
+				if committed, err := time.Parse(time.RFC3339, result.LastCommitDate); err == nil && committed.Before(staleBefore) {
+					result.Stale = true
+				}
+			}
+		}
+
+		governance.Branches = append(governance.Branches, result)
+	}
+
+	return nil
+}
+
+// permissionCache holds per-user lookups made by --resolve-permissions so a
+// run never asks for the same user twice, keyed by "owner/repo/login" for
+// repository permissions and "org/login" for organization roles
+var permissionCache = struct {
+	sync.Mutex
+	roles   map[string]string
+	orgRole map[string]string
+}{roles: map[string]string{}, orgRole: map[string]string{}}
+
+// resolveCollaboratorSources fills in the role name of each collaborator and
+// where the access comes from: a direct grant (or owning the repository), org
+// ownership or the org base permission, and otherwise a team
+func resolveCollaboratorSources(client apiClient, owner, repo string, collaborators []Collaborator) error {
+	direct := map[string]bool{}
+	err := getPaginated(client, fmt.Sprintf("repos/%s/%s/collaborators?affiliation=direct", owner, repo), func(page []struct {
+		Login string `json:"login"`
+	}) {
+		for _, user := range page {
+			direct[user.Login] = true
+		}
+	})
+	if err != nil {
+		return err
+	}
+
+	// Only organizations have a base permission; user accounts answer 404
+	var org struct {
+		DefaultRepositoryPermission string `json:"default_repository_permission"`
+	}
+	isOrg := true
+	if err := client.Get(fmt.Sprintf("orgs/%s", owner), &org); err != nil {
+		if !isNotFound(err) {
+			return err
+		}
+		isOrg = false
+	}
+
+	for i := range collaborators {
+		collaborator := &collaborators[i]
+
+		roleName, err := collaboratorRole(client, owner, repo, collaborator.Login)
+		if err != nil {
+			return err
+		}
+		collaborator.RoleName = roleName
+
+		switch {
+		case direct[collaborator.Login] || collaborator.Login == owner || !isOrg:
+			collaborator.Source = "direct"
+		default:
+			orgRole, err := organizationRole(client, owner, collaborator.Login)
+			if err != nil {
+				return err
+			}
+			base := org.DefaultRepositoryPermission
+			if orgRole == "admin" || (base != "" && base != "none" && utils.PermissionRank(base) >= utils.PermissionRank(collaborator.Permission)) {
+				collaborator.Source = "organization"
+			} else {
+				collaborator.Source = "team"
+			}
+		}
+	}
+	return nil
+}
+
+func collaboratorRole(client apiClient, owner, repo, login string) (string, error) {
+	key := fmt.Sprintf("%s/%s/%s", owner, repo, login)
+	permissionCache.Lock()
+	role, ok := permissionCache.roles[key]
+	permissionCache.Unlock()
+	if ok {
+		return role, nil
+	}
+
+	var response struct {
+		RoleName string `json:"role_name"`
+	}
+	if err := client.Get(fmt.Sprintf("repos/%s/%s/collaborators/%s/permission", owner, repo, login), &response); err != nil {
+		return "", err
+	}
+
+	permissionCache.Lock()
+	permissionCache.roles[key] = response.RoleName
+	permissionCache.Unlock()
+	return response.RoleName, nil
+}
+
+// organizationRole returns "admin" or "member", or "" for users who aren't
+// members, such as outside collaborators added through a team
+func organizationRole(client apiClient, org, login string) (string, error) {
+	key := org + "/" + login
+	permissionCache.Lock()
+	role, ok := permissionCache.orgRole[key]
+	permissionCache.Unlock()
+	if ok {
+		return role, nil
+	}
+
+	var membership struct {
+		Role string `json:"role"`
+	}
+	err := client.Get(fmt.Sprintf("orgs/%s/memberships/%s", org, login), &membership)
+	if err != nil && !isNotFound(err) && !isForbidden(err) {
+		return "", err
+	}
+
+	permissionCache.Lock()
+	permissionCache.orgRole[key] = membership.Role
+	permissionCache.Unlock()
+	return membership.Role, nil
+}
+
+// getTagRules lists the rulesets that target tags. The list response carries
+// the target and enforcement, so only tag rulesets are fetched in full for
+// their ref name conditions.
+func getTagRules(client apiClient, owner, repo string, governance *GovernanceConfig) error {
+	type rulesetSummary struct {
+		ID          int    `json:"id"`
+		Name        string `json:"name"`
+		Target      string `json:"target"`
+		Enforcement string `json:"enforcement"`
+	}
+	var rulesetList []rulesetSummary
+
+	// Like getRulesets, include the organization rulesets that apply here
+	err := getPaginated(client, fmt.Sprintf("repos/%s/%s/rulesets?includes_parents=true", owner, repo), func(page []rulesetSummary) {
+		rulesetList = append(rulesetList, page...)
+	})
+	if err != nil {
+		// Servers without rulesets have no tag rules either
+		if isNotFound(err) {
+			return nil
+		}
+		return err
+	}
+
+	for _, summary := range rulesetList {
+		if summary.Target != "tag" {
+			continue
+		}
+
+		var ruleset rulesetResponse
+		if err := client.Get(fmt.Sprintf("repos/%s/%s/rulesets/%d", owner, repo, summary.ID), &ruleset); err != nil {
+			return err
+		}
+
+		pattern := "*"
+		if len(ruleset.Conditions.RefName.Include) > 0 {
+			pattern = strings.Join(ruleset.Conditions.RefName.Include, ", ")
+		}
+
+		governance.TagRules = append(governance.TagRules, TagRule{
+			Name:        summary.Name,
+			Pattern:     pattern,
+			Enforcement: summary.Enforcement,
+		})
+	}
+
+	return nil
+}
+
+// getCommunityFiles reads the community profile, which reports the license,
+// contributing guide, code of conduct and README in one call. The profile
+// has no security policy entry, so SECURITY.md is looked up in the same
+// places GitHub checks for templates.
+func getCommunityFiles(client apiClient, owner, repo string, governance *GovernanceConfig) error {
+	var profile struct {
+		HealthPercentage int `json:"health_percentage"`
+		Files            struct {
+			License *struct {
+				SPDXID string `json:"spdx_id"`
+			} `json:"license"`
+			Contributing  *struct{} `json:"contributing"`
+			CodeOfConduct *struct{} `json:"code_of_conduct"`
+			Readme        *struct{} `json:"readme"`
+		} `json:"files"`
+	}
+
+	err := client.Get(fmt.Sprintf("repos/%s/%s/community/profile", owner, repo), &profile)
+	if err != nil {
+		// The profile is only available for public repositories
+		if isNotFound(err) {
+			logger.Info(fmt.Sprintf("community profile is not available for %s/%s", owner, repo), repoAttr(owner, repo))
+			return nil
+		}
+		return err
+	}
+
+	community := &CommunityProfile{
+		HealthPercentage: profile.HealthPercentage,
+		HasLicense:       profile.Files.License != nil,
+		HasContributing:  profile.Files.Contributing != nil,
+		HasCodeOfConduct: profile.Files.CodeOfConduct != nil,
+		HasReadme:        profile.Files.Readme != nil,
+	}
+	if profile.Files.License != nil && profile.Files.License.SPDXID != "NOASSERTION" {
+		community.License = profile.Files.License.SPDXID
+	}
+
+	for _, dir := range templateDirs {
+		entries, err := listContents(client, owner, repo, dir)
+		if err != nil {
+			return err
+		}
+		for _, entry := range entries {
+			if entry.Type == "file" && strings.EqualFold(entry.Name, "SECURITY.md") {
+				community.HasSecurityPolicy = true
+			}
+		}
+	}
+
+	governance.CommunityFiles = community
+	return nil
+}
+
+// resolveCollaboratorActivity sets each collaborator's LastCommitDate from
+// their most recent commit on the default branch, at most --concurrency
+// lookups at a time. Collaborators without commits keep an empty date.
+func resolveCollaboratorActivity(client apiClient, owner, repo string, collaborators []Collaborator) error {
+	limit := concurrency
+	if limit < 1 {
+		limit = 1
+	}
+	sem := make(chan struct{}, limit)
+
+	inactiveBefore := time.Now().AddDate(0, 0, -inactiveDays)
+	errs := make([]error, len(collaborators))
+	var wg sync.WaitGroup
+	for i := range collaborators {
+		wg.Add(1)
+		go func(collaborator *Collaborator, errp *error) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			var commits []struct {
+				Commit struct {
+					Committer struct {
+						Date string `json:"date"`
+					} `json:"committer"`
+				} `json:"commit"`
+			}
+			err := client.Get(fmt.Sprintf("repos/%s/%s/commits?author=%s&per_page=1", owner, repo, url.QueryEscape(collaborator.Login)), &commits)
+			if err != nil && !isConflict(err) {
+				*errp = err
+				return
+			}
+			if len(commits) > 0 {
+				collaborator.LastCommitDate = commits[0].Commit.Committer.Date
+			}
+
+			if inactiveDays > 0 {
+				committed, err := time.Parse(time.RFC3339, collaborator.LastCommitDate)
+				collaborator.Inactive = err != nil || committed.Before(inactiveBefore)
+			}
+		}(&collaborators[i], &errs[i])
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// maxEvents is how far back the events API pages: it serves at most 300
+// events and nothing older than 90 days, whichever comes first
+const maxEvents = 300
+
+// getRecentEvents collects the last --event-limit governance-relevant
+// entries of the repository event feed. This is best effort: the feed only
+// covers the past 90 days (and at most 300 events, so a busy repository's
+// window is shorter), and changes such as ruleset edits or archival only
+// appear in the organization audit log, which needs an enterprise plan.
+func getRecentEvents(client apiClient, owner, repo string, governance *GovernanceConfig) error {
+	if !withAudit {
+		return nil
+	}
+
+	var events []Event
+	for page := 1; page <= maxEvents/perPage && len(events) < eventLimit; page++ {
+		var response []struct {
+			Type  string `json:"type"`
+			Actor struct {
+				Login string `json:"login"`
+			} `json:"actor"`
+			CreatedAt string `json:"created_at"`
+			Payload   struct {
+				Action  string `json:"action"`
+				RefType string `json:"ref_type"`
+				Member  struct {
+					Login string `json:"login"`
+				} `json:"member"`
+			} `json:"payload"`
+		}
+		err := client.Get(fmt.Sprintf("repos/%s/%s/events?per_page=%d&page=%d", owner, repo, perPage, page), &response)
+		if err != nil {
+			if isNotFound(err) {
+				logger.Info(fmt.Sprintf("the event feed of %s/%s is not available", owner, repo), repoAttr(owner, repo))
+				return nil
+			}
+			return err
+		}
+
+		for _, entry := range response {
+			event := Event{Actor: entry.Actor.Login, CreatedAt: entry.CreatedAt}
+			switch {
+			case entry.Type == "MemberEvent":
+				event.Type = "member_" + entry.Payload.Action
+				event.Detail = entry.Payload.Member.Login
+			case entry.Type == "PublicEvent":
+				event.Type = "visibility_public"
+			case entry.Type == "CreateEvent" && entry.Payload.RefType == "repository":
+				event.Type = "repository_created"
+			default:
+				continue
+			}
+			events = append(events, event)
+		}
+
+		if len(response) < perPage {
+			break
+		}
+	}
+
+	// Report newest first without relying on the feed's ordering
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].CreatedAt > events[j].CreatedAt
+	})
+	if len(events) > eventLimit {
+		events = events[:eventLimit]
+	}
+	governance.RecentEvents = events
+	return nil
+}
+
+// recentCheckPulls is how many recently updated pull requests have their
+// head commit searched for check runs. Workflows triggered only by
+// pull_request never report on the default branch, so its head alone would
+// make their required checks look orphaned.
+const recentCheckPulls = 3
+
+// markOrphanedChecks flags the required status checks of each ruleset that
+// no check run or commit status reports on the default branch head or the
+// heads of recently updated pull requests. A check can be missing for other
+// reasons (path filters, a skipped workflow), so the result is a hint. The
+// lookup is an addition to the rulesets section and its failure only leaves
+// the checks unflagged.
+func markOrphanedChecks(client apiClient, owner, repo string, governance *GovernanceConfig) {
+	required := false
+	for _, ruleset := range governance.Rulesets {
+		required = required || len(ruleset.RequiredStatusChecks) > 0
+	}
+	if !required {
+		return
+	}
+
+	seen, err := recentCheckNames(client, owner, repo)
+	if err != nil {
+		logger.Warn(fmt.Sprintf("could not look up recent check runs of %s/%s, orphaned required checks are not reported (%v)", owner, repo, err), repoAttr(owner, repo))
+		return
+	}
+
+	for i := range governance.Rulesets {
+		ruleset := &governance.Rulesets[i]
+		for _, check := range ruleset.RequiredStatusChecks {
+			matched := false
+			for _, name := range seen {
+				if utils.CheckNameMatches(check, name) {
+					matched = true
+					break
+				}
+			}
+			if !matched {
+				ruleset.OrphanedChecks = append(ruleset.OrphanedChecks, check)
+			}
+		}
+	}
+}
+
+// recentCheckNames returns the check run names and commit status contexts
+// reported on the default branch head and recent pull request heads
+func recentCheckNames(client apiClient, owner, repo string) ([]string, error) {
+	defaultBranch, err := getDefaultBranch(client, owner, repo)
+	if err != nil {
+		return nil, err
+	}
+	refs := []string{defaultBranch}
+
+	var pulls []struct {
+		Head struct {
+			SHA string `json:"sha"`
+		} `json:"head"`
+	}
+	err = client.Get(fmt.Sprintf("repos/%s/%s/pulls?state=all&sort=updated&direction=desc&per_page=%d", owner, repo, recentCheckPulls), &pulls)
+	if err != nil {
+		return nil, err
+	}
+	for _, pull := range pulls {
+		refs = append(refs, pull.Head.SHA)
+	}
+
+	var names []string
+	for _, ref := range refs {
+		for page := 1; ; page++ {
+			var response struct {
+				CheckRuns []struct {
+					Name string `json:"name"`
+				} `json:"check_runs"`
+			}
+			err := client.Get(fmt.Sprintf("repos/%s/%s/commits/%s/check-runs?per_page=%d&page=%d", owner, repo, url.PathEscape(ref), perPage, page), &response)
+			// An empty repository has no default branch commit
+			if isNotFound(err) || isConflict(err) {
+				break
+			}
+			if err != nil {
+				return nil, err
+			}
+			for _, run := range response.CheckRuns {
+				names = append(names, run.Name)
+			}
+			if len(response.CheckRuns) < perPage {
+				break
+			}
+		}
+
+		// Statuses from external CI satisfy required checks too
+		var status struct {
+			Statuses []struct {
+				Context string `json:"context"`
+			} `json:"statuses"`
+		}
+		err := client.Get(fmt.Sprintf("repos/%s/%s/commits/%s/status", owner, repo, url.PathEscape(ref)), &status)
+		if isNotFound(err) || isConflict(err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		for _, entry := range status.Statuses {
+			names = append(names, entry.Context)
+		}
+	}
+	return names, nil
+}
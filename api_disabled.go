@@ -1,11 +0,0 @@
-// This file contains the real GitHub API implementation
-// It's disabled to prevent build hanging issues with the go-gh library
-// To enable real GitHub API integration, rename this file to api.go
-// and update the go.mod to include the github.com/cli/go-gh/v2 dependency
-
-//go:build disabled
-
-package main
-
-// Real GitHub API implementation would go here
-// Currently disabled to prevent hanging during builds and tests
\ No newline at end of file
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+	"testing"
+)
+
+// pagedClient serves numbered items in pages of perPage, total in all, and
+// records the requested paths
+type pagedClient struct {
+	total    int
+	requests []string
+}
+
+func (c *pagedClient) Get(path string, response interface{}) error {
+	c.requests = append(c.requests, path)
+
+	parsed, err := url.Parse(path)
+	if err != nil {
+		return err
+	}
+	page, err := strconv.Atoi(parsed.Query().Get("page"))
+	if err != nil {
+		return err
+	}
+	var items []int
+	for i := (page - 1) * perPage; i < page*perPage && i < c.total; i++ {
+		items = append(items, i)
+	}
+	data, err := json.Marshal(items)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, response)
+}
+
+func TestGetPaginatedCollectsPages(t *testing.T) {
+	client := &pagedClient{total: perPage + 7}
+
+	var items []int
+	err := getPaginated(client, "repos/o/r/labels", func(page []int) {
+		items = append(items, page...)
+	})
+	if err != nil {
+		t.Fatalf("getPaginated: %v", err)
+	}
+
+	if len(items) != client.total {
+		t.Errorf("collected %d items, want %d", len(items), client.total)
+	}
+	for i, item := range items {
+		if item != i {
+			t.Fatalf("items[%d] = %d, want %d", i, item, i)
+		}
+	}
+
+	want := []string{
+		fmt.Sprintf("repos/o/r/labels?per_page=%d&page=1", perPage),
+		fmt.Sprintf("repos/o/r/labels?per_page=%d&page=2", perPage),
+	}
+	if fmt.Sprint(client.requests) != fmt.Sprint(want) {
+		t.Errorf("requests = %v, want %v (paging should stop on the short page)", client.requests, want)
+	}
+}
@@ -0,0 +1,113 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	expectedBranch    string
+	branchAuditFormat string
+)
+
+// branchMismatch is a repository whose default branch isn't --expected
+type branchMismatch struct {
+	Repo          string `json:"repo"`
+	DefaultBranch string `json:"default_branch"`
+}
+
+func newDefaultBranchAuditCmd() *cobra.Command {
+	auditCmd := &cobra.Command{
+		Use:   "default-branch-audit (--org <org> | --repos-file <file>)",
+		Short: "List repositories whose default branch isn't the expected one",
+		Long: `List the repositories of an organization or a repos file whose default
+branch differs from --expected, followed by a count. Only the default branch
+is read, so this is much cheaper than a full inspection: a single listing for
+--org and one request per repository for --repos-file.`,
+		Args: cobra.NoArgs,
+		RunE: runDefaultBranchAudit,
+	}
+
+	auditCmd.Flags().StringVar(&expectedBranch, "expected", "main", "Default branch every repository should use")
+	auditCmd.Flags().StringVarP(&branchAuditFormat, "format", "f", "text", "Output format (text, json)")
+	auditCmd.Flags().StringVar(&orgName, "org", "", "Audit every repository in an organization")
+	auditCmd.Flags().StringVar(&reposFile, "repos-file", "", "Audit the owner/repo entries listed one per line in this file")
+	auditCmd.Flags().IntVar(&repoLimit, "limit", 0, "Maximum number of repositories to audit with --org (0 for no limit)")
+	auditCmd.Flags().BoolVar(&includeForks, "include-forks", false, "With --org, also audit forked repositories")
+	auditCmd.Flags().BoolVar(&excludeArchived, "exclude-archived", true, "With --org, skip archived repositories")
+	auditCmd.MarkFlagsMutuallyExclusive("org", "repos-file")
+	auditCmd.MarkFlagsOneRequired("org", "repos-file")
+
+	return auditCmd
+}
+
+func runDefaultBranchAudit(cmd *cobra.Command, args []string) error {
+	format := strings.ToLower(branchAuditFormat)
+	if format != "text" && format != "json" {
+		return fmt.Errorf("unsupported default-branch-audit format: %s", branchAuditFormat)
+	}
+
+	client, err := newRESTClient(context.Background())
+	if err != nil {
+		return err
+	}
+
+	var audited int
+	mismatches := []branchMismatch{}
+	check := func(fullName, branch string) {
+		audited++
+		if branch != expectedBranch {
+			mismatches = append(mismatches, branchMismatch{Repo: fullName, DefaultBranch: branch})
+		}
+	}
+
+	if orgName != "" {
+		// The listing already carries each repository's default branch
+		listed, err := listOrgRepos(client, orgName, repoLimit)
+		if err != nil {
+			return fmt.Errorf("failed to list repositories for %s: %v", orgName, err)
+		}
+		for _, repo := range listed {
+			check(orgName+"/"+repo.Name, repo.DefaultBranch)
+		}
+	} else {
+		repos, err := readReposFile(reposFile)
+		if err != nil {
+			return err
+		}
+		for _, repo := range repos {
+			fullName := repo.Owner + "/" + repo.Name
+			var settings struct {
+				DefaultBranch string `json:"default_branch"`
+			}
+			if err := client.Get(fmt.Sprintf("repos/%s/%s", repo.Owner, repo.Name), &settings); err != nil {
+				logger.Warn(fmt.Sprintf("failed to read %s: %v", fullName, err), slog.String("repo", fullName))
+				continue
+			}
+			check(fullName, settings.DefaultBranch)
+		}
+	}
+
+	if format == "json" {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(mismatches)
+	}
+	return outputBranchAuditText(os.Stdout, mismatches, audited)
+}
+
+func outputBranchAuditText(out io.Writer, mismatches []branchMismatch, audited int) error {
+	w := &errWriter{w: out}
+	for _, mismatch := range mismatches {
+		fmt.Fprintf(w, "%s:%s\n", mismatch.Repo, mismatch.DefaultBranch)
+	}
+	fmt.Fprintf(w, "%d of %d repositories not on %s\n", len(mismatches), audited, expectedBranch)
+	return w.err
+}
@@ -0,0 +1,253 @@
+package main
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/cli/go-gh/v2/pkg/term"
+	"github.com/jefeish/gh-repo-inspect/utils"
+	"github.com/spf13/cobra"
+)
+
+func newBrowseCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "browse [owner/repo]",
+		Short: "Explore a repository's governance in an interactive tree",
+		Long: `Inspect a repository and browse the report as a tree of sections, items and
+details. Use the arrow keys (or j/k) to move, right/enter to expand, left to
+collapse and q to quit. Requires an interactive terminal.`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: runBrowse,
+	}
+}
+
+func runBrowse(cmd *cobra.Command, args []string) error {
+	if !term.FromEnv().IsTerminalOutput() {
+		return fmt.Errorf("browse requires an interactive terminal; use --format table for piped output")
+	}
+
+	var repo string
+	if len(args) == 0 {
+		currentRepo, err := getCurrentRepo()
+		if err != nil {
+			return fmt.Errorf("no repository specified and could not determine current repository: %v", err)
+		}
+		repo = currentRepo
+	} else {
+		repo = args[0]
+	}
+
+	repoHost, owner, repoName, err := utils.ParseRepoArg(repo)
+	if err != nil {
+		return err
+	}
+	if host == "" && repoHost != "" {
+		host = repoHost
+	}
+
+	governance, err := inspectRepository(owner, repoName)
+	if err != nil {
+		return fmt.Errorf("failed to inspect repository: %v", err)
+	}
+
+	root := &browseNode{label: owner + "/" + repoName, expanded: true}
+	root.setChildren(browseChildren(reflect.ValueOf(*governance)))
+	_, err = tea.NewProgram(&browseModel{root: root}, tea.WithAltScreen()).Run()
+	return err
+}
+
+// browseNode is one line of the tree; only nodes with children expand
+type browseNode struct {
+	label    string
+	children []*browseNode
+	expanded bool
+	parent   *browseNode
+}
+
+// setChildren links children back to the node, so collapsing from a leaf
+// can find the node to close
+func (n *browseNode) setChildren(children []*browseNode) {
+	n.children = children
+	for _, child := range children {
+		child.parent = n
+	}
+}
+
+// browseChildren turns the fields of a struct into nodes, skipping empty
+// strings, lists and pointers: nested structs and lists become expandable
+// nodes and scalars leaves
+func browseChildren(v reflect.Value) []*browseNode {
+	var nodes []*browseNode
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Field(i)
+		switch field.Kind() {
+		case reflect.Ptr, reflect.Slice, reflect.String:
+			if field.IsZero() {
+				continue
+			}
+		}
+		if node := browseValue(fieldName(v.Type().Field(i)), field); node != nil {
+			nodes = append(nodes, node)
+		}
+	}
+	return nodes
+}
+
+func browseValue(label string, v reflect.Value) *browseNode {
+	for v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return nil
+		}
+		v = v.Elem()
+	}
+
+	node := &browseNode{label: label}
+	switch {
+	case v.Kind() == reflect.Struct:
+		node.setChildren(browseChildren(v))
+	case v.Kind() == reflect.Slice && v.Type().Elem().Kind() == reflect.Struct:
+		// Items are labelled by their identifying first field, like diff paths
+		node.label = fmt.Sprintf("%s (%d)", label, v.Len())
+		items := make([]*browseNode, v.Len())
+		for i := range items {
+			items[i] = &browseNode{label: fmt.Sprint(elementKey(v.Index(i)))}
+			items[i].setChildren(browseChildren(v.Index(i)))
+		}
+		node.setChildren(items)
+	case v.Kind() == reflect.Slice:
+		values := make([]string, v.Len())
+		for i := range values {
+			values[i] = fmt.Sprint(v.Index(i).Interface())
+		}
+		node.label = fmt.Sprintf("%s: %s", label, strings.Join(values, ", "))
+	case v.Kind() == reflect.Bool:
+		node.label = fmt.Sprintf("%s: %s", label, boolToIcon(v.Bool()))
+	default:
+		node.label = fmt.Sprintf("%s: %v", label, v.Interface())
+	}
+	return node
+}
+
+// browseLine is a visible node with its depth in the tree
+type browseLine struct {
+	node  *browseNode
+	depth int
+}
+
+type browseModel struct {
+	root   *browseNode
+	cursor int
+	offset int
+	height int
+}
+
+func (m *browseModel) Init() tea.Cmd {
+	return nil
+}
+
+// lines flattens the expanded part of the tree below the root
+func (m *browseModel) lines() []browseLine {
+	var lines []browseLine
+	var walk func(node *browseNode, depth int)
+	walk = func(node *browseNode, depth int) {
+		for _, child := range node.children {
+			lines = append(lines, browseLine{node: child, depth: depth})
+			if child.expanded {
+				walk(child, depth+1)
+			}
+		}
+	}
+	walk(m.root, 0)
+	return lines
+}
+
+func (m *browseModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.height = msg.Height
+	case tea.KeyMsg:
+		lines := m.lines()
+		if len(lines) == 0 {
+			return m, tea.Quit
+		}
+		current := lines[m.cursor].node
+
+		switch msg.String() {
+		case "q", "esc", "ctrl+c":
+			return m, tea.Quit
+		case "up", "k":
+			if m.cursor > 0 {
+				m.cursor--
+			}
+		case "down", "j":
+			if m.cursor < len(lines)-1 {
+				m.cursor++
+			}
+		case "right", "l", "enter", " ":
+			if len(current.children) > 0 {
+				current.expanded = !current.expanded || msg.String() == "right" || msg.String() == "l"
+			}
+		case "left", "h":
+			// Collapse the current node, or jump to its parent when it is a leaf
+			if current.expanded {
+				current.expanded = false
+			} else if current.parent != nil && current.parent != m.root {
+				current.parent.expanded = false
+				for i, line := range m.lines() {
+					if line.node == current.parent {
+						m.cursor = i
+					}
+				}
+			}
+		}
+	}
+
+	m.scroll()
+	return m, nil
+}
+
+// scroll keeps the cursor within the visible window
+func (m *browseModel) scroll() {
+	visible := m.height - 3
+	if visible < 1 {
+		return
+	}
+	if m.cursor < m.offset {
+		m.offset = m.cursor
+	}
+	if m.cursor >= m.offset+visible {
+		m.offset = m.cursor - visible + 1
+	}
+}
+
+func (m *browseModel) View() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s\n\n", m.root.label)
+
+	lines := m.lines()
+	end := len(lines)
+	if visible := m.height - 3; visible > 0 && m.offset+visible < end {
+		end = m.offset + visible
+	}
+
+	for i := m.offset; i < end; i++ {
+		line := lines[i]
+		cursor := "  "
+		if i == m.cursor {
+			cursor = "> "
+		}
+		marker := "  "
+		if len(line.node.children) > 0 {
+			marker = "▸ "
+			if line.node.expanded {
+				marker = "▾ "
+			}
+		}
+		fmt.Fprintf(&b, "%s%s%s%s\n", cursor, strings.Repeat("  ", line.depth), marker, line.node.label)
+	}
+
+	fmt.Fprintf(&b, "\n↑/↓ move  →/enter expand  ← collapse  q quit")
+	return b.String()
+}
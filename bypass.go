@@ -0,0 +1,159 @@
+package main
+
+import (
+	"fmt"
+)
+
+// Bypass actor types, as named by the REST API
+const (
+	bypassTeam              = "Team"
+	bypassIntegration       = "Integration"
+	bypassRepositoryRole    = "RepositoryRole"
+	bypassOrganizationAdmin = "OrganizationAdmin"
+	bypassDeployKey         = "DeployKey"
+)
+
+// builtinRepositoryRoles names the base roles rulesets refer to by fixed
+// ID; custom roles are looked up in the organization
+var builtinRepositoryRoles = map[int]string{
+	2: "maintain",
+	4: "write",
+	5: "admin",
+}
+
+// String renders a bypass actor as "@team", "app:slug" or a role name,
+// followed by its bypass mode
+func (b BypassActor) String() string {
+	name := b.Name
+	switch b.Type {
+	case bypassTeam:
+		name = "@" + b.Name
+	case bypassIntegration:
+		name = "app:" + b.Name
+	case bypassRepositoryRole:
+		name = "role:" + b.Name
+	}
+	if b.Mode == "" {
+		return name
+	}
+	return fmt.Sprintf("%s (%s)", name, b.Mode)
+}
+
+// bypassActorStrings renders each actor with String for list-style outputs
+func bypassActorStrings(actors []BypassActor) []string {
+	names := make([]string, 0, len(actors))
+	for _, actor := range actors {
+		names = append(names, actor.String())
+	}
+	return names
+}
+
+// restBypassActor mirrors an entry of a REST ruleset's bypass_actors, which
+// is only returned to callers who can edit the ruleset
+type restBypassActor struct {
+	ActorID    int    `json:"actor_id"`
+	ActorType  string `json:"actor_type"`
+	BypassMode string `json:"bypass_mode"`
+}
+
+// bypassActorResolver turns the numeric actor IDs of REST rulesets into
+// names. Each organization listing is fetched at most once, and only when a
+// ruleset refers to that kind of actor.
+type bypassActorResolver struct {
+	client apiClient
+	owner  string
+	teams  map[int]string
+	apps   map[int]string
+	roles  map[int]string
+}
+
+func newBypassActorResolver(client apiClient, owner string) *bypassActorResolver {
+	return &bypassActorResolver{client: client, owner: owner}
+}
+
+func (r *bypassActorResolver) resolve(actors []restBypassActor) []BypassActor {
+	var resolved []BypassActor
+	for _, actor := range actors {
+		bypass := BypassActor{Type: actor.ActorType, Mode: actor.BypassMode}
+		switch actor.ActorType {
+		case bypassTeam:
+			bypass.Name = r.lookup(&r.teams, r.listTeams, actor.ActorID)
+		case bypassIntegration:
+			bypass.Name = r.lookup(&r.apps, r.listApps, actor.ActorID)
+		case bypassRepositoryRole:
+			if name, ok := builtinRepositoryRoles[actor.ActorID]; ok {
+				bypass.Name = name
+			} else {
+				bypass.Name = r.lookup(&r.roles, r.listRoles, actor.ActorID)
+			}
+		case bypassOrganizationAdmin:
+			bypass.Name = "organization-admin"
+		case bypassDeployKey:
+			bypass.Name = "deploy-key"
+		default:
+			bypass.Name = fmt.Sprintf("#%d", actor.ActorID)
+		}
+		resolved = append(resolved, bypass)
+	}
+	return resolved
+}
+
+// lookup returns the name for id from the lazily listed names, or "#id"
+// when the listing isn't readable or doesn't contain it
+func (r *bypassActorResolver) lookup(names *map[int]string, list func() (map[int]string, error), id int) string {
+	if *names == nil {
+		listed, err := list()
+		if err != nil {
+			logger.Warn(fmt.Sprintf("could not resolve bypass actor names for %s: %v", r.owner, err))
+			listed = map[int]string{}
+		}
+		*names = listed
+	}
+	if name, ok := (*names)[id]; ok {
+		return name
+	}
+	return fmt.Sprintf("#%d", id)
+}
+
+func (r *bypassActorResolver) listTeams() (map[int]string, error) {
+	teams := map[int]string{}
+	err := getPaginated(r.client, fmt.Sprintf("orgs/%s/teams", r.owner), func(page []struct {
+		ID   int    `json:"id"`
+		Slug string `json:"slug"`
+	}) {
+		for _, team := range page {
+			teams[team.ID] = team.Slug
+		}
+	})
+	return teams, err
+}
+
+func (r *bypassActorResolver) listApps() (map[int]string, error) {
+	var response struct {
+		Installations []struct {
+			AppID   int    `json:"app_id"`
+			AppSlug string `json:"app_slug"`
+		} `json:"installations"`
+	}
+	apps := map[int]string{}
+	err := r.client.Get(fmt.Sprintf("orgs/%s/installations?per_page=%d", r.owner, perPage), &response)
+	for _, installation := range response.Installations {
+		apps[installation.AppID] = installation.AppSlug
+	}
+	return apps, err
+}
+
+func (r *bypassActorResolver) listRoles() (map[int]string, error) {
+	var response struct {
+		CustomRoles []struct {
+			ID   int    `json:"id"`
+			Name string `json:"name"`
+		} `json:"custom_roles"`
+	}
+	roles := map[int]string{}
+	err := r.client.Get(fmt.Sprintf("orgs/%s/custom-repository-roles", r.owner), &response)
+	for _, role := range response.CustomRoles {
+		roles[role.ID] = role.Name
+	}
+	return roles, err
+}
@@ -0,0 +1,102 @@
+package main
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// cachingClient serves GET responses from disk while they are younger than
+// ttl and refreshes them from the wrapped client otherwise. Only successful
+// responses are cached.
+type cachingClient struct {
+	client apiClient
+	dir    string
+	ttl    time.Duration
+}
+
+func newCachingClient(client apiClient, dir string, ttl time.Duration) (*cachingClient, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, fmt.Errorf("failed to create cache directory: %v", err)
+	}
+	return &cachingClient{client: client, dir: dir, ttl: ttl}, nil
+}
+
+func (c *cachingClient) requestContext() context.Context {
+	return requestContext(c.client)
+}
+
+func (c *cachingClient) responseHeaders(path string, body interface{}) (http.Header, error) {
+	return responseHeaders(c.client, path, body)
+}
+
+func (c *cachingClient) Get(path string, response interface{}) error {
+	cacheFile := c.cacheFile(path)
+
+	if info, err := os.Stat(cacheFile); err == nil && time.Since(info.ModTime()) < c.ttl {
+		data, err := os.ReadFile(cacheFile)
+		if err == nil {
+			logger.Debug(fmt.Sprintf("Cache hit: %s", path))
+			return decodeCached(data, response)
+		}
+	}
+
+	var raw json.RawMessage
+	if err := c.client.Get(path, &raw); err != nil {
+		return err
+	}
+
+	// A failed cache write only costs a future API call
+	if err := writeCacheFile(cacheFile, raw); err != nil {
+		logger.Warn(fmt.Sprintf("failed to cache %s: %v", path, err))
+	}
+
+	return decodeCached(raw, response)
+}
+
+func (c *cachingClient) cacheFile(path string) string {
+	sum := sha256.Sum256([]byte(path))
+	return filepath.Join(c.dir, hex.EncodeToString(sum[:])+".json")
+}
+
+// decodeCached unmarshals a cached body; empty bodies come from 204 responses
+func decodeCached(data []byte, response interface{}) error {
+	if len(data) == 0 || response == nil {
+		return nil
+	}
+	return json.Unmarshal(data, response)
+}
+
+// writeCacheFile writes through a temporary file so concurrent readers never
+// observe a partial entry. The org scan manifest is written the same way.
+func writeCacheFile(path string, data []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), "entry-*")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// cacheHostDir keeps entries for different hosts apart
+func cacheHostDir(host string) string {
+	if host == "" {
+		return "default"
+	}
+	return strings.ReplaceAll(host, string(filepath.Separator), "_")
+}
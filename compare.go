@@ -0,0 +1,162 @@
+package main
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// difference is a single field that differs between two configurations
+type difference struct {
+	Path  string      `json:"path"`
+	Left  interface{} `json:"left"`
+	Right interface{} `json:"right"`
+}
+
+// compareGovernance walks both configurations field by field. In partial
+// mode right is treated as a baseline: its zero values are ignored and only
+// its list elements must be present in left. Otherwise the comparison is
+// symmetric and elements missing from either side are reported.
+func compareGovernance(left, right *GovernanceConfig, partial bool) []difference {
+	var diffs []difference
+	compareValues("", reflect.ValueOf(*left), reflect.ValueOf(*right), partial, &diffs)
+	return diffs
+}
+
+// withoutActivity returns a copy of governance without the repository
+// activity timestamps, metrics, alert counts, recent events, collaborator
+// commit dates and orphaned checks, which change with every push, star or
+// workflow run and always differ between repositories, so comparisons only
+// report configuration changes
+func withoutActivity(governance *GovernanceConfig) *GovernanceConfig {
+	stripped := *governance
+	stripped.RepoSettings.CreatedAt = ""
+	stripped.RepoSettings.UpdatedAt = ""
+	stripped.RepoSettings.PushedAt = ""
+	stripped.RepoSettings.SizeKB = 0
+	stripped.RepoSettings.OpenIssues = 0
+	stripped.RepoSettings.Forks = 0
+	stripped.RepoSettings.Stargazers = 0
+	stripped.RepoSettings.Watchers = 0
+	stripped.SecuritySettings.SecretScanningAlertCount = nil
+	stripped.SecuritySettings.DependabotAlertCount = nil
+	stripped.RecentEvents = nil
+	if len(stripped.Collaborators) > 0 {
+		stripped.Collaborators = append([]Collaborator(nil), stripped.Collaborators...)
+		for i := range stripped.Collaborators {
+			stripped.Collaborators[i].LastCommitDate = ""
+			stripped.Collaborators[i].Inactive = false
+		}
+	}
+	if len(stripped.Rulesets) > 0 {
+		stripped.Rulesets = append([]Ruleset(nil), stripped.Rulesets...)
+		for i := range stripped.Rulesets {
+			stripped.Rulesets[i].OrphanedChecks = nil
+		}
+	}
+	if stripped.Summary != nil {
+		summary := *stripped.Summary
+		summary.OrphanedChecks = nil
+		stripped.Summary = &summary
+	}
+	return &stripped
+}
+
+func compareValues(path string, left, right reflect.Value, partial bool, diffs *[]difference) {
+	if partial && right.IsZero() {
+		return
+	}
+
+	switch right.Kind() {
+	case reflect.Ptr:
+		if left.IsNil() && right.IsNil() {
+			return
+		}
+		if left.IsNil() || right.IsNil() {
+			*diffs = append(*diffs, difference{Path: path, Left: presence(!left.IsNil()), Right: presence(!right.IsNil())})
+			return
+		}
+		compareValues(path, left.Elem(), right.Elem(), partial, diffs)
+	case reflect.Struct:
+		for i := 0; i < right.NumField(); i++ {
+			field := right.Type().Field(i)
+			compareValues(joinPath(path, fieldName(field)), left.Field(i), right.Field(i), partial, diffs)
+		}
+	case reflect.Slice:
+		compareSlices(path, left, right, partial, diffs)
+	default:
+		if !reflect.DeepEqual(left.Interface(), right.Interface()) {
+			*diffs = append(*diffs, difference{Path: path, Left: left.Interface(), Right: right.Interface()})
+		}
+	}
+}
+
+// compareSlices matches list elements by identity: struct elements by their
+// first field (name, login, title, ...) and scalar elements by value.
+// Matching struct elements are then compared field by field.
+func compareSlices(path string, left, right reflect.Value, partial bool, diffs *[]difference) {
+	for i := 0; i < right.Len(); i++ {
+		want := right.Index(i)
+		elementPath := fmt.Sprintf("%s[%v]", path, elementKey(want))
+		match, found := findElement(left, want)
+		if !found {
+			*diffs = append(*diffs, difference{Path: elementPath, Left: presence(false), Right: presence(true)})
+			continue
+		}
+		if want.Kind() == reflect.Struct {
+			compareValues(elementPath, match, want, partial, diffs)
+		}
+	}
+
+	if partial {
+		return
+	}
+
+	for i := 0; i < left.Len(); i++ {
+		have := left.Index(i)
+		if _, found := findElement(right, have); !found {
+			elementPath := fmt.Sprintf("%s[%v]", path, elementKey(have))
+			*diffs = append(*diffs, difference{Path: elementPath, Left: presence(true), Right: presence(false)})
+		}
+	}
+}
+
+func findElement(slice, element reflect.Value) (reflect.Value, bool) {
+	key := elementKey(element)
+	for i := 0; i < slice.Len(); i++ {
+		if reflect.DeepEqual(elementKey(slice.Index(i)), key) {
+			return slice.Index(i), true
+		}
+	}
+	return reflect.Value{}, false
+}
+
+func elementKey(element reflect.Value) interface{} {
+	if element.Kind() == reflect.Struct {
+		return element.Field(0).Interface()
+	}
+	return element.Interface()
+}
+
+func presence(present bool) string {
+	if present {
+		return "present"
+	}
+	return "missing"
+}
+
+// fieldName returns the JSON name of a struct field so paths read like the report
+func fieldName(field reflect.StructField) string {
+	name := strings.Split(field.Tag.Get("json"), ",")[0]
+	if name == "" {
+		return field.Name
+	}
+	return name
+}
+
+func joinPath(parent, child string) string {
+	if parent == "" {
+		return child
+	}
+	return parent + "." + child
+}
@@ -0,0 +1,143 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/jefeish/gh-repo-inspect/utils"
+	"github.com/spf13/cobra"
+)
+
+var (
+	templateRepo          string
+	compareTemplateFormat string
+)
+
+// templateGap is a setting the template configures that the target lacks
+type templateGap struct {
+	Setting  string      `json:"setting"`
+	Path     string      `json:"path"`
+	Template interface{} `json:"template"`
+	Target   interface{} `json:"target"`
+}
+
+// templateReport is the JSON output of compare-template
+type templateReport struct {
+	Target   string        `json:"target"`
+	Template string        `json:"template"`
+	Missing  []templateGap `json:"missing"`
+}
+
+func newCompareTemplateCmd() *cobra.Command {
+	compareCmd := &cobra.Command{
+		Use:   "compare-template <owner/repo> --template <owner/repo>",
+		Short: "Report governance settings a repository is missing relative to its template",
+		Long: `Inspect a repository and the template repository it was created from and list
+the settings the template configures that the repository lacks. Settings the
+template leaves at their defaults are not reported, and per-repository data
+such as collaborators, webhooks, deploy keys, milestones, releases and
+branches is not compared.`,
+		Args: cobra.ExactArgs(1),
+		RunE: runCompareTemplate,
+	}
+
+	compareCmd.Flags().StringVar(&templateRepo, "template", "", "Template repository to use as the baseline (owner/repo)")
+	compareCmd.Flags().StringVarP(&compareTemplateFormat, "format", "f", "text", "Output format (text, json)")
+	_ = compareCmd.MarkFlagRequired("template")
+
+	return compareCmd
+}
+
+func runCompareTemplate(cmd *cobra.Command, args []string) error {
+	var configs []*GovernanceConfig
+	for _, arg := range []string{args[0], templateRepo} {
+		_, owner, repo, err := utils.ParseRepoArg(arg)
+		if err != nil {
+			return err
+		}
+
+		logger.Info(fmt.Sprintf("Inspecting repository: %s/%s", owner, repo), repoAttr(owner, repo))
+
+		governance, err := inspectRepository(owner, repo)
+		if err != nil {
+			return fmt.Errorf("failed to inspect %s/%s: %v", owner, repo, err)
+		}
+		configs = append(configs, governance)
+	}
+
+	target, template := configs[0], configs[1]
+	report := templateReport{
+		Target:   target.Repository.Owner + "/" + target.Repository.Name,
+		Template: template.Repository.Owner + "/" + template.Repository.Name,
+		Missing:  templateGaps(target, template),
+	}
+
+	switch strings.ToLower(compareTemplateFormat) {
+	case "json":
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(report)
+	case "text":
+		return outputTemplateText(os.Stdout, report)
+	default:
+		return fmt.Errorf("unsupported compare-template format: %s", compareTemplateFormat)
+	}
+}
+
+// templateGaps compares target against template in baseline mode, so only
+// the template's non-default settings and list entries are required
+func templateGaps(target, template *GovernanceConfig) []templateGap {
+	targetCopy, templateCopy := settingsOnly(target), settingsOnly(template)
+
+	gaps := []templateGap{}
+	for _, diff := range compareGovernance(targetCopy, templateCopy, true) {
+		gaps = append(gaps, templateGap{Setting: settingName(diff.Path), Path: diff.Path, Template: diff.Right, Target: diff.Left})
+	}
+	return gaps
+}
+
+// settingsOnly drops the parts of a report that naturally differ between a
+// template and the repositories created from it
+func settingsOnly(governance *GovernanceConfig) *GovernanceConfig {
+	settings := *withoutActivity(governance)
+	settings.Repository = RepoInfo{}
+	settings.Summary = nil
+	settings.Collaborators = nil
+	settings.Webhooks = nil
+	settings.DeployKeys = nil
+	settings.Milestones = nil
+	settings.Releases = nil
+	settings.Branches = nil
+	return &settings
+}
+
+// settingName turns a difference path into a short setting name: top-level
+// settings use their field name (repository_settings.delete_branch_on_merge
+// becomes delete-branch-on-merge), list entries keep their full path
+func settingName(path string) string {
+	if !strings.Contains(path, "[") {
+		if idx := strings.LastIndex(path, "."); idx >= 0 {
+			path = path[idx+1:]
+		}
+	}
+	return strings.ReplaceAll(path, "_", "-")
+}
+
+func outputTemplateText(out io.Writer, report templateReport) error {
+	w := &errWriter{w: out}
+
+	if len(report.Missing) == 0 {
+		fmt.Fprintf(w, "%s matches template %s\n", report.Target, report.Template)
+		return w.err
+	}
+
+	names := make([]string, 0, len(report.Missing))
+	for _, gap := range report.Missing {
+		names = append(names, gap.Setting)
+	}
+	fmt.Fprintf(w, "%s is missing: %s\n", report.Target, strings.Join(names, ", "))
+	return w.err
+}
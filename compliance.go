@@ -0,0 +1,351 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Violation is a field that doesn't match the policy baseline. Repository is
+// only set in batch output, where violations of several repositories are
+// listed together.
+type Violation struct {
+	Repository string `json:"repository,omitempty" xml:"repository,omitempty"`
+	Path       string `json:"path" xml:"path"`
+	Expected   string `json:"expected" xml:"expected"`
+	Actual     string `json:"actual" xml:"actual"`
+	Severity   string `json:"severity" xml:"severity"`
+}
+
+func (v Violation) String() string {
+	return fmt.Sprintf("%s: expected %s, got %s (%s)", v.Path, v.Expected, v.Actual, v.Severity)
+}
+
+const defaultSeverity = "medium"
+
+var severityLevels = []string{"low", "medium", "high"}
+
+// governancePolicy is a loaded --policy file: the baseline to check against
+// and the severities mapped to its paths
+type governancePolicy struct {
+	baseline   *GovernanceConfig
+	severities map[string]string
+}
+
+// loadPolicy reads a governance baseline from a YAML file shaped like the
+// --format yaml output. An optional top-level severity mapping assigns
+// severities to paths, e.g. security_settings.secret_scanning: high.
+func loadPolicy(path string) (*governancePolicy, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read policy file: %v", err)
+	}
+
+	var policy GovernanceConfig
+	if err := yaml.Unmarshal(data, &policy); err != nil {
+		return nil, fmt.Errorf("failed to parse policy file: %v", err)
+	}
+
+	var settings struct {
+		Severity map[string]string `yaml:"severity"`
+	}
+	if err := yaml.Unmarshal(data, &settings); err != nil {
+		return nil, fmt.Errorf("failed to parse policy file: %v", err)
+	}
+	for path, severity := range settings.Severity {
+		if !slices.Contains(severityLevels, severity) {
+			return nil, fmt.Errorf("invalid severity %q for %s in policy file (available: %s)", severity, path, strings.Join(severityLevels, ", "))
+		}
+	}
+
+	return &governancePolicy{baseline: &policy, severities: settings.Severity}, nil
+}
+
+// checkCompliance compares the inspected configuration against an expected
+// baseline and returns one violation per mismatching field, all of the
+// default severity. Zero values in the baseline are ignored so partial
+// policies only assert what they specify.
+func checkCompliance(actual, expected *GovernanceConfig) []Violation {
+	return checkPolicy(actual, &governancePolicy{baseline: expected})
+}
+
+// checkPolicy is checkCompliance against a loaded policy, with the
+// severities of its mapping
+func checkPolicy(actual *GovernanceConfig, policy *governancePolicy) []Violation {
+	var violations []Violation
+	for _, diff := range compareGovernance(actual, policy.baseline, true) {
+		violations = append(violations, Violation{
+			Path:     diff.Path,
+			Expected: fmt.Sprint(diff.Right),
+			Actual:   fmt.Sprint(diff.Left),
+			Severity: violationSeverity(diff.Path, policy.severities),
+		})
+	}
+	return violations
+}
+
+// reportsViolations reports whether a format carries the --policy violations
+// in the report's violations field, next to the governance data
+func reportsViolations(format string) bool {
+	format = strings.ToLower(format)
+	return format == "json" || format == "ndjson"
+}
+
+// violationSeverity returns the severity of the longest mapped path that is
+// path itself or one of its parents, so mapping security_settings covers
+// every security setting
+func violationSeverity(path string, severities map[string]string) string {
+	severity, matched := defaultSeverity, -1
+	for prefix, level := range severities {
+		if len(prefix) <= matched {
+			continue
+		}
+		if path == prefix || strings.HasPrefix(path, prefix+".") || strings.HasPrefix(path, prefix+"[") {
+			severity, matched = level, len(prefix)
+		}
+	}
+	return severity
+}
+
+// failCondition is a named check for --fail-on that matches an undesirable
+// governance state. It is only evaluated when its section was inspected.
+// level and description describe the weakness in SARIF output.
+type failCondition struct {
+	key         string
+	section     string
+	level       string
+	description string
+	check       func(governance *GovernanceConfig) bool
+}
+
+var failConditions = []failCondition{
+	{key: "no-secret-scanning", section: "security", level: "error", description: "Secret scanning is disabled", check: func(g *GovernanceConfig) bool {
+		return !g.SecuritySettings.SecretScanning
+	}},
+	{key: "no-push-protection", section: "security", level: "warning", description: "Secret scanning push protection is disabled", check: func(g *GovernanceConfig) bool {
+		return !g.SecuritySettings.SecretScanningPushProtection
+	}},
+	{key: "no-vulnerability-alerts", section: "security", level: "warning", description: "Dependabot vulnerability alerts are disabled", check: func(g *GovernanceConfig) bool {
+		return !g.SecuritySettings.VulnerabilityAlerts
+	}},
+	{key: "allows-force-push", section: "rulesets", level: "error", description: "A branch covered by a ruleset allows force pushes", check: func(g *GovernanceConfig) bool {
+		return branchLeftOpen(g, func(ruleset Ruleset) bool { return ruleset.AllowForcePushes })
+	}},
+	{key: "allows-deletions", section: "rulesets", level: "warning", description: "A branch covered by a ruleset allows deletion", check: func(g *GovernanceConfig) bool {
+		return branchLeftOpen(g, func(ruleset Ruleset) bool { return ruleset.AllowDeletions })
+	}},
+	{key: "no-branch-protection", section: "rulesets", level: "error", description: "No rulesets or branch protection are configured", check: func(g *GovernanceConfig) bool {
+		return len(activeRulesets(g)) == 0 && len(g.ProtectedBranches) == 0
+	}},
+	{key: "no-signed-commits", section: "rulesets", level: "warning", description: "No rule requires signed commits", check: func(g *GovernanceConfig) bool {
+		for _, ruleset := range activeRulesets(g) {
+			if ruleset.RequireSignedCommits {
+				return false
+			}
+		}
+		for _, branch := range g.ProtectedBranches {
+			if branch.RequireSignedCommits {
+				return false
+			}
+		}
+		return true
+	}},
+	{key: "no-required-reviews", section: "rulesets", level: "warning", description: "No rule requires pull request reviews", check: func(g *GovernanceConfig) bool {
+		for _, ruleset := range activeRulesets(g) {
+			if ruleset.RequiredPullRequestReviews {
+				return false
+			}
+		}
+		for _, branch := range g.ProtectedBranches {
+			if branch.RequiredReviews > 0 {
+				return false
+			}
+		}
+		return true
+	}},
+	{key: "orphaned-checks", section: "rulesets", level: "warning", description: "A ruleset requires a status check that no recent run reports", check: func(g *GovernanceConfig) bool {
+		for _, ruleset := range activeRulesets(g) {
+			if len(ruleset.OrphanedChecks) > 0 {
+				return true
+			}
+		}
+		return false
+	}},
+	{key: "unprotected-default", section: "rulesets", level: "error", description: "No ruleset or branch protection covers the default branch", check: func(g *GovernanceConfig) bool {
+		// Without the settings there is no default branch to look for
+		return g.RepoSettings.DefaultBranch != "" && !defaultBranchProtected(g)
+	}},
+	{key: "stale", section: "settings", level: "warning", description: "The repository has not been pushed to within --stale-days", check: func(g *GovernanceConfig) bool {
+		// Without --stale-days (e.g. in SARIF output) there is no threshold
+		if staleDays <= 0 {
+			return false
+		}
+		pushed, err := time.Parse(time.RFC3339, g.RepoSettings.PushedAt)
+		return err == nil && pushed.Before(time.Now().AddDate(0, 0, -staleDays))
+	}},
+}
+
+// activeRulesets returns the rulesets that are enforced. Evaluate-mode and
+// disabled rulesets don't protect anything, so fail conditions ignore them;
+// a missing enforcement (e.g. from a policy file) counts as active.
+func activeRulesets(g *GovernanceConfig) []Ruleset {
+	var active []Ruleset
+	for _, ruleset := range g.Rulesets {
+		if ruleset.Enforcement == "" || ruleset.Enforcement == "active" {
+			active = append(active, ruleset)
+		}
+	}
+	return active
+}
+
+// branchLeftOpen reports whether a branch covered by an active ruleset is
+// allowed by every active ruleset covering it. Rulesets layer, so a single
+// restricting one is enough to close a branch another leaves open. The
+// branches looked at are the default branch and the include patterns
+// themselves, which stand for the branches they match.
+func branchLeftOpen(g *GovernanceConfig, allows func(Ruleset) bool) bool {
+	active := activeRulesets(g)
+	defaultBranch := g.RepoSettings.DefaultBranch
+	for _, ruleset := range active {
+		if !allows(ruleset) {
+			continue
+		}
+		for _, branch := range coveredBranches(ruleset, defaultBranch) {
+			if !rulesetCoversBranch(ruleset, branch, defaultBranch) {
+				continue
+			}
+			restricted := false
+			for _, other := range active {
+				if !allows(other) && rulesetCoversBranch(other, branch, defaultBranch) {
+					restricted = true
+					break
+				}
+			}
+			if !restricted {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// coveredBranches returns the candidate branches of a ruleset for
+// branchLeftOpen, before its exclude conditions are applied
+func coveredBranches(ruleset Ruleset, defaultBranch string) []string {
+	include := ruleset.RefNameInclude
+	if len(include) == 0 {
+		include = []string{ruleset.Pattern}
+	}
+	var branches []string
+	if defaultBranch != "" {
+		branches = append(branches, defaultBranch)
+	}
+	for _, ref := range include {
+		if ref == "~DEFAULT_BRANCH" {
+			continue
+		}
+		branches = append(branches, strings.TrimPrefix(ref, "refs/heads/"))
+	}
+	return branches
+}
+
+// defaultBranchProtected reports whether an active ruleset or a branch
+// protection rule matches the default branch
+func defaultBranchProtected(g *GovernanceConfig) bool {
+	branch := g.RepoSettings.DefaultBranch
+	if branch == "" {
+		return false
+	}
+	for _, ruleset := range activeRulesets(g) {
+		if rulesetCoversBranch(ruleset, branch, branch) {
+			return true
+		}
+	}
+	for _, protection := range g.ProtectedBranches {
+		if matchesAny(branch, []string{protection.Name}) {
+			return true
+		}
+	}
+	return false
+}
+
+// rulesetCoversBranch reports whether a ruleset's ref name conditions match
+// branch, with the fnmatch patterns of matchesAny and ~DEFAULT_BRANCH
+// standing for defaultBranch. Rulesets without conditions (classic
+// protection, policy files) match on their Pattern.
+func rulesetCoversBranch(ruleset Ruleset, branch, defaultBranch string) bool {
+	include := ruleset.RefNameInclude
+	if len(include) == 0 {
+		include = []string{ruleset.Pattern}
+	}
+	matches := func(refs []string) bool {
+		for _, ref := range refs {
+			if ref == "~DEFAULT_BRANCH" {
+				if branch == defaultBranch {
+					return true
+				}
+				continue
+			}
+			if matchesAny(branch, []string{strings.TrimPrefix(ref, "refs/heads/")}) {
+				return true
+			}
+		}
+		return false
+	}
+	return matches(include) && !matches(ruleset.RefNameExclude)
+}
+
+// failConditionByKey looks up a condition, returning nil for unknown keys
+func failConditionByKey(key string) *failCondition {
+	for i := range failConditions {
+		if failConditions[i].key == key {
+			return &failConditions[i]
+		}
+	}
+	return nil
+}
+
+// allFailConditionKeys returns every condition key, e.g. to evaluate them all
+func allFailConditionKeys() []string {
+	keys := make([]string, 0, len(failConditions))
+	for _, condition := range failConditions {
+		keys = append(keys, condition.key)
+	}
+	return keys
+}
+
+// failConditionKeys lists the available --fail-on keys for the flag help
+func failConditionKeys() string {
+	return strings.Join(allFailConditionKeys(), ", ")
+}
+
+// validateFailConditions rejects unknown --fail-on keys before any API calls
+func validateFailConditions(keys []string) error {
+	for _, key := range keys {
+		if failConditionByKey(key) == nil {
+			return fmt.Errorf("unknown --fail-on condition %q (available: %s)", key, failConditionKeys())
+		}
+		if key == "stale" && staleDays <= 0 {
+			return fmt.Errorf("--fail-on stale requires --stale-days")
+		}
+	}
+	return nil
+}
+
+// checkFailConditions returns the requested conditions that match governance
+func checkFailConditions(governance *GovernanceConfig, keys []string) []string {
+	var tripped []string
+	for _, condition := range failConditions {
+		for _, key := range keys {
+			if condition.key == key && shouldIncludeSection(condition.section) && condition.check(governance) {
+				tripped = append(tripped, condition.key)
+				break
+			}
+		}
+	}
+	return tripped
+}
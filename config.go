@@ -0,0 +1,98 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+// configFileName is looked up in the working directory, then in $HOME
+const configFileName = ".repo-inspect.yml"
+
+// findConfigFile returns --config when set, otherwise the first default
+// config file that exists, or "" when there is none
+func findConfigFile() (string, error) {
+	if configFile != "" {
+		if _, err := os.Stat(configFile); err != nil {
+			return "", fmt.Errorf("failed to read config file: %v", err)
+		}
+		return configFile, nil
+	}
+
+	dirs := []string{"."}
+	if home, err := os.UserHomeDir(); err == nil {
+		dirs = append(dirs, home)
+	}
+	for _, dir := range dirs {
+		path := filepath.Join(dir, configFileName)
+		if _, err := os.Stat(path); err == nil {
+			return path, nil
+		} else if !errors.Is(err, os.ErrNotExist) {
+			return "", fmt.Errorf("failed to read config file: %v", err)
+		}
+	}
+	return "", nil
+}
+
+// applyConfigFile sets every flag named in the config file that wasn't given
+// on the command line, so explicit flags always win. Keys are flag names;
+// lists are joined with commas for slice flags such as sections.
+func applyConfigFile(cmd *cobra.Command) error {
+	path, err := findConfigFile()
+	if err != nil || path == "" {
+		return err
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read config file: %v", err)
+	}
+
+	var values map[string]interface{}
+	if err := yaml.Unmarshal(data, &values); err != nil {
+		return fmt.Errorf("failed to parse config file %s: %v", path, err)
+	}
+
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	root := cmd.Root()
+	for _, key := range keys {
+		if key == "config" || (root.Flags().Lookup(key) == nil && root.PersistentFlags().Lookup(key) == nil) {
+			return fmt.Errorf("unknown key %q in config file %s", key, path)
+		}
+
+		// Flags of the root command don't all apply to subcommands
+		flag := cmd.Flags().Lookup(key)
+		if flag == nil || flag.Changed {
+			continue
+		}
+
+		if err := cmd.Flags().Set(key, configValue(values[key])); err != nil {
+			return fmt.Errorf("invalid value for %q in config file %s: %v", key, path, err)
+		}
+	}
+
+	logger.Info(fmt.Sprintf("Loaded config file: %s", path))
+	return nil
+}
+
+func configValue(value interface{}) string {
+	if items, ok := value.([]interface{}); ok {
+		parts := make([]string, len(items))
+		for i, item := range items {
+			parts[i] = fmt.Sprint(item)
+		}
+		return strings.Join(parts, ",")
+	}
+	return fmt.Sprint(value)
+}
@@ -0,0 +1,145 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/jefeish/gh-repo-inspect/utils"
+	"github.com/pmezard/go-difflib/difflib"
+	"github.com/spf13/cobra"
+)
+
+var diffFormat string
+
+func newDiffCmd() *cobra.Command {
+	diffCmd := &cobra.Command{
+		Use:   "diff <owner/repo> <owner/repo>",
+		Short: "Show governance differences between two repositories",
+		Long: `Inspect two repositories and print every field that differs between them,
+grouped by section. List entries present in only one repository are reported
+as missing on the other side.
+
+--format unified prints a unified diff of the two reports as YAML instead,
+with lists sorted as by --normalize so ordering doesn't show up as changes.`,
+		Args: cobra.ExactArgs(2),
+		RunE: runDiff,
+	}
+
+	diffCmd.Flags().StringVarP(&diffFormat, "format", "f", "text", "Output format (text, json, unified)")
+
+	return diffCmd
+}
+
+func runDiff(cmd *cobra.Command, args []string) error {
+	if err := validateRedactCategories(redactCategories); err != nil {
+		return err
+	}
+
+	var configs []*GovernanceConfig
+	for _, arg := range args {
+		_, owner, repo, err := utils.ParseRepoArg(arg)
+		if err != nil {
+			return err
+		}
+
+		logger.Info(fmt.Sprintf("Inspecting repository: %s/%s", owner, repo), repoAttr(owner, repo))
+
+		governance, err := inspectRepository(owner, repo)
+		if err != nil {
+			return fmt.Errorf("failed to inspect %s/%s: %v", owner, repo, err)
+		}
+		configs = append(configs, redact(governance, redactCategories))
+	}
+
+	diffs := diffRepositories(configs[0], configs[1])
+
+	switch strings.ToLower(diffFormat) {
+	case "json":
+		// Always emit an array so consumers don't have to special-case null
+		if diffs == nil {
+			diffs = []difference{}
+		}
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(diffs)
+	case "text":
+		return outputDiffText(os.Stdout, configs[0], configs[1], diffs)
+	case "unified":
+		return outputDiffUnified(os.Stdout, configs[0], configs[1])
+	default:
+		return fmt.Errorf("unsupported diff format: %s", diffFormat)
+	}
+}
+
+// diffRepositories compares two inspected repositories, ignoring their
+// owner/name which always differ
+func diffRepositories(left, right *GovernanceConfig) []difference {
+	leftCopy, rightCopy := *left, *right
+	leftCopy.Repository = RepoInfo{}
+	rightCopy.Repository = RepoInfo{}
+	return compareGovernance(withoutActivity(&leftCopy), withoutActivity(&rightCopy), false)
+}
+
+func outputDiffText(out io.Writer, left, right *GovernanceConfig, diffs []difference) error {
+	w := &errWriter{w: out}
+
+	fmt.Fprintf(w, "Governance diff: %s/%s ↔ %s/%s\n\n",
+		left.Repository.Owner, left.Repository.Name,
+		right.Repository.Owner, right.Repository.Name)
+
+	if len(diffs) == 0 {
+		fmt.Fprintf(w, "No differences found\n")
+		return w.err
+	}
+
+	// Differences arrive in field order, so a section change starts a new group
+	currentSection := ""
+	for _, diff := range diffs {
+		section := strings.SplitN(strings.SplitN(diff.Path, ".", 2)[0], "[", 2)[0]
+		if section != currentSection {
+			if currentSection != "" {
+				fmt.Fprintln(w)
+			}
+			fmt.Fprintf(w, "%s\n", section)
+			currentSection = section
+		}
+		fmt.Fprintf(w, "  %s: %v != %v\n", diff.Path, diff.Left, diff.Right)
+	}
+
+	return w.err
+}
+
+// outputDiffUnified prints a unified diff of the two reports' YAML, which is
+// empty when they match. Both are normalized first and, as in the field
+// diff, their owner/name and activity data are left out.
+func outputDiffUnified(out io.Writer, left, right *GovernanceConfig) error {
+	documents := make([]string, 2)
+	for i, governance := range []*GovernanceConfig{left, right} {
+		stripped := *governance
+		stripped.Repository = RepoInfo{}
+		normalized := withoutActivity(&stripped)
+		normalizeGovernance(normalized)
+
+		var buf bytes.Buffer
+		if err := outputYAML(&buf, normalized); err != nil {
+			return err
+		}
+		documents[i] = buf.String()
+	}
+
+	diff := difflib.UnifiedDiff{
+		A:        difflib.SplitLines(documents[0]),
+		B:        difflib.SplitLines(documents[1]),
+		FromFile: left.Repository.Owner + "/" + left.Repository.Name,
+		ToFile:   right.Repository.Owner + "/" + right.Repository.Name,
+		Context:  3,
+	}
+	if err := difflib.WriteUnifiedDiff(out, diff); err != nil {
+		return fmt.Errorf("failed to write diff: %v", err)
+	}
+	return nil
+}
@@ -0,0 +1,156 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/cli/go-gh/v2/pkg/auth"
+	"github.com/cli/go-gh/v2/pkg/term"
+	"github.com/jefeish/gh-repo-inspect/utils"
+	"github.com/spf13/cobra"
+)
+
+var doctorStrict bool
+
+// doctorCheck is one line of the doctor checklist. run returns what it found
+// and, when the check fails, a hint on how to fix it. When a prerequisite
+// fails, the checks after it are skipped since they would fail the same way.
+type doctorCheck struct {
+	name         string
+	prerequisite bool
+	run          func() (detail, hint string, err error)
+}
+
+func newDoctorCmd() *cobra.Command {
+	doctorCmd := &cobra.Command{
+		Use:   "doctor [owner/repo]",
+		Short: "Check credentials, connectivity and rate limit",
+		Long: `Check the setup the inspection depends on and print a checklist with a hint
+for every failed check:
+
+- a token is available for the host (--token, GH_TOKEN or gh auth login)
+- the host answers GET /meta (--host or GH_HOST for GitHub Enterprise Server)
+- the remaining REST rate limit
+- the token can read a sample endpoint: the repository when one is given,
+  otherwise the authenticated user
+
+The checks use the same client as an inspection, without the response cache.
+The command always exits zero unless --strict is given.`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: runDoctor,
+	}
+
+	doctorCmd.Flags().BoolVar(&doctorStrict, "strict", false, "Exit with an error when a check fails")
+
+	return doctorCmd
+}
+
+func runDoctor(cmd *cobra.Command, args []string) error {
+	sample := "user"
+	if len(args) == 1 {
+		_, owner, repo, err := utils.ParseRepoArg(args[0])
+		if err != nil {
+			return err
+		}
+		sample = fmt.Sprintf("repos/%s/%s", owner, repo)
+	}
+
+	// A cached answer would hide the very failures the checks look for
+	noCache = true
+	utils.PlainOutput = os.Getenv("NO_COLOR") != "" || !term.FromEnv().IsTerminalOutput()
+
+	host := resolveHost()
+	if host == "" {
+		host, _ = auth.DefaultHost()
+	}
+
+	checks := []doctorCheck{
+		{name: "Credentials", prerequisite: true, run: func() (string, string, error) {
+			if authToken != "" {
+				return fmt.Sprintf("token from --token for %s", host), "", nil
+			}
+			if token, source := auth.TokenForHost(host); token != "" {
+				return fmt.Sprintf("token from %s for %s", source, host), "", nil
+			}
+			return "", "pass --token, set GH_TOKEN (GH_ENTERPRISE_TOKEN for GHES), or run gh auth login --hostname " + host,
+				fmt.Errorf("no token found for %s", host)
+		}},
+		{name: "Connectivity", prerequisite: true, run: func() (string, string, error) {
+			if err := doctorGet("meta"); err != nil {
+				if isUnauthorized(err) {
+					return "", "the host rejected the token: run gh auth refresh or create a new token", err
+				}
+				return "", "check --host or GH_HOST, and any proxy settings (HTTPS_PROXY)", err
+			}
+			return fmt.Sprintf("%s answers GET /meta", host), "", nil
+		}},
+		{name: "Rate limit", run: func() (string, string, error) {
+			status, err := getRateLimit()
+			if err != nil {
+				return "", "the API may be unreachable or the token rejected, see the checks above", err
+			}
+			if status.Core.Remaining == 0 {
+				return "", "wait for the reset, or use --cache-dir to reuse earlier responses",
+					fmt.Errorf("core quota exhausted: %s", status.Core)
+			}
+			return fmt.Sprintf("core %s, graphql %s", status.Core, status.GraphQL), "", nil
+		}},
+		{name: "Token access", run: func() (string, string, error) {
+			err := doctorGet(sample)
+			switch {
+			case err == nil:
+				return fmt.Sprintf("GET /%s succeeded", sample), "", nil
+			case sample == "user" && isAppScopeForbidden(err):
+				// Installation tokens can't read /user but are still valid
+				return "GitHub App installation token (GET /user is not available to apps)", "", nil
+			case isUnauthorized(err):
+				return "", "the token is invalid or expired: run gh auth refresh or create a new token", err
+			case isNotFound(err) || isForbidden(err):
+				return "", "the token can't read it: grant it access to the repository (repo scope for classic tokens)", err
+			}
+			return "", "", err
+		}},
+	}
+
+	passed, failed := 0, 0
+	blocked := ""
+	for _, check := range checks {
+		if blocked != "" {
+			fmt.Printf("%sSKIP %s: needs a passing %s check\n", icon("⏭️  "), check.name, blocked)
+			continue
+		}
+		detail, hint, err := check.run()
+		if err != nil {
+			failed++
+			fmt.Printf("%sFAIL %s: %v\n", icon("❌ "), check.name, err)
+			if hint != "" {
+				fmt.Printf("     Hint: %s\n", hint)
+			}
+			if check.prerequisite {
+				blocked = check.name
+			}
+			continue
+		}
+		passed++
+		fmt.Printf("%sPASS %s: %s\n", icon("✅ "), check.name, detail)
+	}
+
+	fmt.Printf("\n%d of %d checks passed\n", passed, len(checks))
+	if failed > 0 && doctorStrict {
+		cmd.SilenceUsage = true
+		return fmt.Errorf("%d doctor check(s) failed", failed)
+	}
+	return nil
+}
+
+// doctorGet makes a request through the client an inspection would use
+func doctorGet(path string) error {
+	ctx, cancel := inspectContext()
+	defer cancel()
+	client, err := newRESTClient(ctx)
+	if err != nil {
+		return err
+	}
+	var response interface{}
+	return client.Get(path, &response)
+}
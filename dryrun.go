@@ -0,0 +1,58 @@
+package main
+
+import (
+	"fmt"
+	"sync"
+)
+
+// dryRunClient records the requests a fetcher makes instead of calling the
+// API. Every request succeeds with an empty response, so the recording shows
+// the requests made for a repository with nothing configured: list
+// endpoints stop after their first page and follow-up requests for
+// individual items (ruleset details, branch comparisons) are not made.
+type dryRunClient struct {
+	mu       sync.Mutex
+	requests []string
+}
+
+func (c *dryRunClient) Get(path string, response interface{}) error {
+	c.record("GET " + path)
+	return nil
+}
+
+func (c *dryRunClient) record(request string) {
+	c.mu.Lock()
+	c.requests = append(c.requests, request)
+	c.mu.Unlock()
+}
+
+// runDryRun prints the requests each enabled section would make, in
+// section order, by running the real fetchers against a dryRunClient so the
+// list can't drift from the actual requests
+func runDryRun(owner, repo string) error {
+	total := 0
+	for _, fetcher := range sectionFetchers {
+		if fetcher.section != "" && !shouldIncludeSection(fetcher.section) {
+			continue
+		}
+
+		client := &dryRunClient{}
+		// Errors come from parsing the empty responses, not from the API
+		_ = fetcher.fetch(client, owner, repo, &GovernanceConfig{})
+
+		fmt.Printf("# %s\n", fetcher.label)
+		for _, request := range client.requests {
+			fmt.Println(request)
+		}
+		total += len(client.requests)
+	}
+
+	fmt.Printf("# %d requests for %s/%s", total, owner, repo)
+	if host := resolveHost(); host != "" {
+		fmt.Printf(" on %s", host)
+	}
+	fmt.Println()
+
+	logger.Info("list endpoints are shown with their first page; repositories with more data also request further pages and individual items")
+	return nil
+}
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+// validateExecHook rejects flag combinations --exec can't honour before any
+// API calls are made
+func validateExecHook() error {
+	switch {
+	case execCommand == "":
+		return nil
+	case strings.ToLower(outputFormat) != "json":
+		return fmt.Errorf("--exec pipes the JSON report to the command and requires --format json")
+	case outputFile != "":
+		return fmt.Errorf("--exec streams the command's output to stdout and cannot be combined with --output")
+	case outputDir != "":
+		return fmt.Errorf("--exec streams the command's output to stdout and cannot be combined with --output-dir")
+	case watch:
+		return fmt.Errorf("--exec cannot be combined with --watch")
+	}
+	return nil
+}
+
+// runExecHook renders the report, pipes it to --exec on stdin and streams
+// the command's output through. The command runs in the user's shell so
+// pipelines and quoting work as typed; a non-zero exit becomes our exit code.
+func runExecHook(render func(w io.Writer) error) error {
+	var report bytes.Buffer
+	if err := render(&report); err != nil {
+		return err
+	}
+
+	shell, flag := "sh", "-c"
+	if runtime.GOOS == "windows" {
+		shell, flag = "cmd", "/C"
+	}
+
+	logger.Info(fmt.Sprintf("Running --exec command: %s", execCommand))
+
+	command := exec.Command(shell, flag, execCommand)
+	command.Stdin = &report
+	command.Stdout = os.Stdout
+	command.Stderr = os.Stderr
+	if err := command.Run(); err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			return &exitError{code: exitErr.ExitCode(), err: fmt.Errorf("--exec command failed: %v", err)}
+		}
+		return fmt.Errorf("failed to run --exec command: %v", err)
+	}
+	return nil
+}
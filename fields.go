@@ -0,0 +1,222 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+)
+
+// fieldSectionAliases lets --fields paths start with a --sections name
+// instead of the GovernanceConfig field it fills
+var fieldSectionAliases = map[string]string{
+	"summary":           "Summary",
+	"rulesets":          "Rulesets",
+	"collaborators":     "Collaborators",
+	"teams":             "Teams",
+	"security":          "SecuritySettings",
+	"settings":          "RepoSettings",
+	"labels":            "IssueLabels",
+	"milestones":        "Milestones",
+	"webhooks":          "Webhooks",
+	"environments":      "Environments",
+	"branch-protection": "ProtectedBranches",
+	"deploy-keys":       "DeployKeys",
+	"actions":           "Actions",
+	"codeowners":        "CodeOwners",
+	"pages":             "Pages",
+	"autolinks":         "Autolinks",
+	"workflows":         "Workflows",
+	"dependabot":        "Dependabot",
+	"releases":          "Releases",
+	"templates":         "Templates",
+	"branches":          "Branches",
+	"tag-rules":         "TagRules",
+	"community-files":   "CommunityFiles",
+	"recent-events":     "RecentEvents",
+}
+
+// fieldPath is a resolved --fields entry: the struct field indexes to follow
+// from GovernanceConfig, and the path as the user wrote it for table labels
+type fieldPath struct {
+	label   string
+	indexes []int
+}
+
+// parseFieldPaths resolves dotted paths such as settings.DefaultBranch.
+// Segments match Go field names or JSON names, case-insensitively; slices
+// are stepped through so rulesets.Name selects the name of every ruleset.
+func parseFieldPaths(paths []string) ([]fieldPath, error) {
+	var resolved []fieldPath
+	for _, path := range paths {
+		t := reflect.TypeOf(GovernanceConfig{})
+		field := fieldPath{label: path}
+		for i, segment := range strings.Split(path, ".") {
+			if alias, ok := fieldSectionAliases[strings.ToLower(segment)]; ok && i == 0 {
+				segment = alias
+			}
+
+			t = elemType(t)
+			if t.Kind() != reflect.Struct {
+				return nil, fmt.Errorf("unknown --fields path %q: %s has no fields", path, strings.Join(strings.Split(path, ".")[:i], "."))
+			}
+
+			index := -1
+			for j := 0; j < t.NumField(); j++ {
+				if strings.EqualFold(t.Field(j).Name, segment) || strings.EqualFold(fieldName(t.Field(j)), segment) {
+					index = j
+					break
+				}
+			}
+			if index < 0 {
+				return nil, fmt.Errorf("unknown --fields path %q (available: %s)", path, strings.Join(fieldNames(t, strings.Split(path, ".")[:i]), ", "))
+			}
+
+			field.indexes = append(field.indexes, index)
+			t = t.Field(index).Type
+		}
+		resolved = append(resolved, field)
+	}
+	return resolved, nil
+}
+
+// elemType steps through pointers and slices to the element type
+func elemType(t reflect.Type) reflect.Type {
+	for t.Kind() == reflect.Ptr || t.Kind() == reflect.Slice {
+		t = t.Elem()
+	}
+	return t
+}
+
+// fieldNames lists the valid paths one level below prefix, for error messages
+func fieldNames(t reflect.Type, prefix []string) []string {
+	var names []string
+	for i := 0; i < t.NumField(); i++ {
+		names = append(names, strings.Join(append(append([]string{}, prefix...), t.Field(i).Name), "."))
+	}
+	return names
+}
+
+// selectFields projects governance onto the selected fields, keeping the
+// JSON names and nesting of the full report. The repository is always kept
+// so batch output stays attributable.
+func selectFields(governance *GovernanceConfig, fields []fieldPath) map[string]interface{} {
+	selected := map[string]interface{}{"repository": governance.Repository}
+	for _, field := range fields {
+		mergeField(selected, reflect.ValueOf(*governance), field.indexes)
+	}
+	return selected
+}
+
+// mergeField copies the value at indexes from v into target, creating the
+// intermediate objects (or arrays of objects for slices) on the way
+func mergeField(target map[string]interface{}, v reflect.Value, indexes []int) {
+	field := v.Type().Field(indexes[0])
+	name := fieldName(field)
+	value := v.Field(indexes[0])
+
+	if len(indexes) == 1 {
+		target[name] = value.Interface()
+		return
+	}
+
+	for value.Kind() == reflect.Ptr {
+		if value.IsNil() {
+			target[name] = nil
+			return
+		}
+		value = value.Elem()
+	}
+
+	if value.Kind() == reflect.Slice {
+		elements, _ := target[name].([]map[string]interface{})
+		if len(elements) != value.Len() {
+			elements = make([]map[string]interface{}, value.Len())
+			for i := range elements {
+				elements[i] = map[string]interface{}{}
+			}
+		}
+		for i := 0; i < value.Len(); i++ {
+			element := value.Index(i)
+			for element.Kind() == reflect.Ptr {
+				element = element.Elem()
+			}
+			mergeField(elements[i], element, indexes[1:])
+		}
+		target[name] = elements
+		return
+	}
+
+	nested, ok := target[name].(map[string]interface{})
+	if !ok {
+		nested = map[string]interface{}{}
+	}
+	mergeField(nested, value, indexes[1:])
+	target[name] = nested
+}
+
+// fieldValues returns the values at indexes, one per element when the path
+// steps through a slice
+func fieldValues(v reflect.Value, indexes []int) []reflect.Value {
+	for v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return nil
+		}
+		v = v.Elem()
+	}
+	if v.Kind() == reflect.Slice && len(indexes) > 0 {
+		var values []reflect.Value
+		for i := 0; i < v.Len(); i++ {
+			values = append(values, fieldValues(v.Index(i), indexes)...)
+		}
+		return values
+	}
+	if len(indexes) == 0 {
+		return []reflect.Value{v}
+	}
+	return fieldValues(v.Field(indexes[0]), indexes[1:])
+}
+
+// outputFieldsTable prints one "path: value" line per selected field, with
+// the values of list elements joined
+func outputFieldsTable(w io.Writer, governance *GovernanceConfig, fields []fieldPath) error {
+	fmt.Fprintf(w, "%sRepository: %s/%s\n", icon("📁 "), governance.Repository.Owner, governance.Repository.Name)
+	for i, field := range fields {
+		prefix := "├─"
+		if i == len(fields)-1 {
+			prefix = "└─"
+		}
+
+		var values []string
+		for _, value := range fieldValues(reflect.ValueOf(*governance), field.indexes) {
+			values = append(values, formatFieldValue(value))
+		}
+		text := strings.Join(values, ", ")
+		if text == "" {
+			text = "-"
+		}
+		fmt.Fprintf(w, "%s %s: %s\n", prefix, field.label, truncateToWidth(text, prefix+" ", field.label+": "))
+	}
+	return nil
+}
+
+func formatFieldValue(value reflect.Value) string {
+	switch value.Kind() {
+	case reflect.Bool:
+		return boolToIcon(value.Bool())
+	case reflect.String:
+		return value.String()
+	case reflect.Slice:
+		if value.Type().Elem().Kind() == reflect.String {
+			return strings.Join(value.Interface().([]string), ", ")
+		}
+	case reflect.Int, reflect.Int64:
+		return fmt.Sprint(value.Interface())
+	}
+	data, err := json.Marshal(value.Interface())
+	if err != nil {
+		return fmt.Sprint(value.Interface())
+	}
+	return string(data)
+}
@@ -0,0 +1,272 @@
+package main
+
+import (
+	"context"
+	"strings"
+
+	"github.com/cli/go-gh/v2/pkg/api"
+	"github.com/jefeish/gh-repo-inspect/utils"
+)
+
+// fetchRulesets picks the GraphQL or REST ruleset path based on --use-graphql
+func fetchRulesets(client apiClient, owner, repo string, governance *GovernanceConfig) error {
+	if useGraphQL {
+		if dryRun, ok := client.(*dryRunClient); ok {
+			dryRun.record("POST graphql (repository rulesets query)")
+			return nil
+		}
+		if err := getRulesetsGraphQL(requestContext(client), owner, repo, governance); err != nil {
+			return err
+		}
+	} else if err := getRulesets(client, owner, repo, governance); err != nil {
+		return err
+	}
+	markOrphanedChecks(client, owner, repo, governance)
+	return nil
+}
+
+func newGraphQLClient() (*api.GraphQLClient, error) {
+	client, err := api.NewGraphQLClient(clientOptions())
+	if err != nil {
+		return nil, authError(err)
+	}
+	return client, nil
+}
+
+const rulesetsQuery = `
+query($owner: String!, $name: String!, $cursor: String) {
+  repository(owner: $owner, name: $name) {
+    rulesets(first: 50, after: $cursor, includeParents: true) {
+      pageInfo {
+        hasNextPage
+        endCursor
+      }
+      nodes {
+        name
+        target
+        enforcement
+        createdAt
+        updatedAt
+        source {
+          __typename
+        }
+        conditions {
+          refName {
+            include
+            exclude
+          }
+        }
+        bypassActors(first: 100) {
+          nodes {
+            bypassMode
+            organizationAdmin
+            repositoryRoleName
+            deployKey
+            actor {
+              __typename
+              ... on App { slug }
+              ... on Team { slug }
+            }
+          }
+        }
+        rules(first: 100) {
+          nodes {
+            type
+            parameters {
+              ... on PullRequestParameters {
+                requiredApprovingReviewCount
+                dismissStaleReviewsOnPush
+                requireCodeOwnerReview
+                requiredReviewThreadResolution
+              }
+              ... on RequiredStatusChecksParameters {
+                requiredStatusChecks {
+                  context
+                }
+              }
+              ... on MergeQueueParameters {
+                mergeMethod
+                minEntriesToMerge
+                maxEntriesToMerge
+              }
+            }
+          }
+        }
+      }
+    }
+  }
+}`
+
+// getRulesetsGraphQL fetches rulesets with their bypass actors and full ref
+// name conditions, which the REST endpoint only partially exposes
+func getRulesetsGraphQL(ctx context.Context, owner, repo string, governance *GovernanceConfig) error {
+	client, err := newGraphQLClient()
+	if err != nil {
+		return err
+	}
+
+	variables := map[string]interface{}{
+		"owner":  owner,
+		"name":   repo,
+		"cursor": nil,
+	}
+
+	for {
+		var response struct {
+			Repository struct {
+				Rulesets struct {
+					PageInfo struct {
+						HasNextPage bool   `json:"hasNextPage"`
+						EndCursor   string `json:"endCursor"`
+					} `json:"pageInfo"`
+					Nodes []struct {
+						Name        string `json:"name"`
+						Target      string `json:"target"`
+						Enforcement string `json:"enforcement"`
+						CreatedAt   string `json:"createdAt"`
+						UpdatedAt   string `json:"updatedAt"`
+						Source      struct {
+							Typename string `json:"__typename"`
+						} `json:"source"`
+						Conditions struct {
+							RefName struct {
+								Include []string `json:"include"`
+								Exclude []string `json:"exclude"`
+							} `json:"refName"`
+						} `json:"conditions"`
+						BypassActors struct {
+							Nodes []graphQLBypassActor `json:"nodes"`
+						} `json:"bypassActors"`
+						Rules struct {
+							Nodes []struct {
+								Type       string `json:"type"`
+								Parameters struct {
+									RequiredApprovingReviewCount   int  `json:"requiredApprovingReviewCount"`
+									DismissStaleReviewsOnPush      bool `json:"dismissStaleReviewsOnPush"`
+									RequireCodeOwnerReview         bool `json:"requireCodeOwnerReview"`
+									RequiredReviewThreadResolution bool `json:"requiredReviewThreadResolution"`
+									RequiredStatusChecks           []struct {
+										Context string `json:"context"`
+									} `json:"requiredStatusChecks"`
+									MergeMethod       string `json:"mergeMethod"`
+									MinEntriesToMerge int    `json:"minEntriesToMerge"`
+									MaxEntriesToMerge int    `json:"maxEntriesToMerge"`
+								} `json:"parameters"`
+							} `json:"nodes"`
+						} `json:"rules"`
+					} `json:"nodes"`
+				} `json:"rulesets"`
+			} `json:"repository"`
+		}
+
+		if err := client.DoWithContext(ctx, rulesetsQuery, variables, &response); err != nil {
+			return err
+		}
+
+		for _, node := range response.Repository.Rulesets.Nodes {
+			// Tag rulesets are reported by the tag-rules section
+			if node.Target == "TAG" {
+				continue
+			}
+
+			pattern := "*" // default
+			if len(node.Conditions.RefName.Include) > 0 {
+				pattern = node.Conditions.RefName.Include[0] // Use first include pattern
+			}
+
+			ruleset := Ruleset{
+				Name:           node.Name,
+				Pattern:        pattern,
+				Enforcement:    strings.ToLower(node.Enforcement),
+				Source:         "repo",
+				RefNameInclude: node.Conditions.RefName.Include,
+				RefNameExclude: node.Conditions.RefName.Exclude,
+				CreatedAt:      utils.NormalizeTimestamp(node.CreatedAt),
+				UpdatedAt:      utils.NormalizeTimestamp(node.UpdatedAt),
+				// Cleared by NON_FAST_FORWARD and DELETION rules below
+				AllowForcePushes: true,
+				AllowDeletions:   true,
+			}
+
+			if node.Source.Typename == "Organization" {
+				ruleset.Source = "organization"
+			}
+
+			for _, actor := range node.BypassActors.Nodes {
+				ruleset.BypassActors = append(ruleset.BypassActors, actor.bypassActor())
+			}
+
+			for _, rule := range node.Rules.Nodes {
+				// GraphQL's RepositoryRuleType is the REST type in upper case
+				ruleset.RuleTypes = append(ruleset.RuleTypes, strings.ToLower(rule.Type))
+				switch rule.Type {
+				case "REQUIRED_STATUS_CHECKS":
+					for _, check := range rule.Parameters.RequiredStatusChecks {
+						ruleset.RequiredStatusChecks = append(ruleset.RequiredStatusChecks, check.Context)
+					}
+				case "PULL_REQUEST":
+					ruleset.RequiredPullRequestReviews = true
+					ruleset.RequiredApprovingReviewCount = rule.Parameters.RequiredApprovingReviewCount
+					ruleset.DismissStaleReviews = rule.Parameters.DismissStaleReviewsOnPush
+					ruleset.RequireCodeOwnerReviews = rule.Parameters.RequireCodeOwnerReview
+					ruleset.RequiredConversationResolution = rule.Parameters.RequiredReviewThreadResolution
+				case "REQUIRED_LINEAR_HISTORY":
+					ruleset.RequiredLinearHistory = true
+				case "REQUIRED_SIGNATURES":
+					ruleset.RequireSignedCommits = true
+				case "NON_FAST_FORWARD":
+					ruleset.AllowForcePushes = false
+				case "DELETION":
+					ruleset.AllowDeletions = false
+				case "MERGE_QUEUE":
+					ruleset.MergeQueueEnabled = true
+					ruleset.MergeQueue = &MergeQueue{
+						MergeMethod:       rule.Parameters.MergeMethod,
+						MinEntriesToMerge: rule.Parameters.MinEntriesToMerge,
+						MaxEntriesToMerge: rule.Parameters.MaxEntriesToMerge,
+					}
+				}
+			}
+
+			governance.Rulesets = append(governance.Rulesets, ruleset)
+		}
+
+		pageInfo := response.Repository.Rulesets.PageInfo
+		if !pageInfo.HasNextPage {
+			return nil
+		}
+		variables["cursor"] = pageInfo.EndCursor
+	}
+}
+
+type graphQLBypassActor struct {
+	BypassMode         string `json:"bypassMode"`
+	OrganizationAdmin  bool   `json:"organizationAdmin"`
+	RepositoryRoleName string `json:"repositoryRoleName"`
+	DeployKey          bool   `json:"deployKey"`
+	Actor              *struct {
+		Typename string `json:"__typename"`
+		Slug     string `json:"slug"`
+	} `json:"actor"`
+}
+
+// bypassActor converts to the REST vocabulary, with GraphQL's upper-case
+// bypass modes lowered to match
+func (b graphQLBypassActor) bypassActor() BypassActor {
+	actor := BypassActor{Mode: strings.ToLower(b.BypassMode)}
+	switch {
+	case b.Actor != nil && b.Actor.Typename == "Team":
+		actor.Type, actor.Name = bypassTeam, b.Actor.Slug
+	case b.Actor != nil && b.Actor.Typename == "App":
+		actor.Type, actor.Name = bypassIntegration, b.Actor.Slug
+	case b.OrganizationAdmin:
+		actor.Type, actor.Name = bypassOrganizationAdmin, "organization-admin"
+	case b.DeployKey:
+		actor.Type, actor.Name = bypassDeployKey, "deploy-key"
+	case b.RepositoryRoleName != "":
+		actor.Type, actor.Name = bypassRepositoryRole, b.RepositoryRoleName
+	default:
+		actor.Name = "unknown"
+	}
+	return actor
+}
@@ -0,0 +1,291 @@
+package main
+
+import (
+	"html/template"
+	"io"
+	"strings"
+)
+
+// htmlFuncs are the helpers available to htmlTemplate. html/template escapes
+// every interpolated value, so repository-provided strings are safe as-is.
+func htmlFuncs(sectionsFilter []string) template.FuncMap {
+	return template.FuncMap{
+		"include": func(section string) bool {
+			return shouldIncludeSectionOutput(section, sectionsFilter)
+		},
+		"yesno": func(value bool) template.HTML {
+			if value {
+				return `<span class="yes">Yes</span>`
+			}
+			return `<span class="no">No</span>`
+		},
+		"visibility": repoVisibility,
+		"actors":     bypassActorStrings,
+		"alerts":     alertCount,
+		"ruleTypes": func(counts ruleTypeCounts) string {
+			return ruleTypeText(counts, ", ")
+		},
+		"target": refTargetText,
+		"source": func(security SecuritySettings, key string) template.HTML {
+			// Sources are fixed constants, never repository-provided text
+			if source := securitySource(security, key); source != sourceRepo {
+				return template.HTML(` <span class="muted">(` + source + `)</span>`)
+			}
+			return ""
+		},
+		"deref": func(value *bool) bool {
+			return *value
+		},
+		"join": func(values []string) string {
+			return strings.Join(values, ", ")
+		},
+	}
+}
+
+// outputHTML renders one self-contained HTML document for all governances
+func outputHTML(w io.Writer, governances []*GovernanceConfig, sectionsFilter []string) error {
+	tmpl, err := template.New("report").Funcs(htmlFuncs(sectionsFilter)).Parse(htmlTemplate)
+	if err != nil {
+		return err
+	}
+	return tmpl.Execute(w, governances)
+}
+
+const htmlTemplate = `<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>Repository Governance Report</title>
+<style>
+body { font-family: -apple-system, "Segoe UI", Helvetica, Arial, sans-serif; margin: 2rem; color: #1f2328; }
+h1 { border-bottom: 1px solid #d0d7de; padding-bottom: .3rem; }
+h2 { margin-top: 2rem; }
+table { border-collapse: collapse; margin-bottom: 1rem; }
+th, td { border: 1px solid #d0d7de; padding: .35rem .75rem; text-align: left; vertical-align: top; }
+th { background: #f6f8fa; }
+code { font-family: ui-monospace, Menlo, monospace; }
+.yes { color: #1a7f37; font-weight: 600; }
+.no { color: #cf222e; font-weight: 600; }
+.muted { color: #656d76; }
+</style>
+</head>
+<body>
+{{range .}}
+<h1>{{.Repository.Owner}}/{{.Repository.Name}}</h1>
+{{if and .Summary (include "summary")}}{{with .Summary}}
+<h2>Summary</h2>
+<table>
+<tr><th>Risk score</th><td>{{.RiskScore}}/100</td></tr>
+<tr><th>Rulesets</th><td>{{.RulesetCount}}</td></tr>
+<tr><th>Protected branches</th><td>{{.ProtectedBranchCount}}</td></tr>
+<tr><th>Default branch protected</th><td>{{yesno .DefaultBranchProtected}}</td></tr>
+<tr><th>Secret scanning</th><td>{{yesno .HasSecretScanning}}</td></tr>
+<tr><th>Collaborators</th><td>{{.CollaboratorCount}} ({{.AdminCount}} admin)</td></tr>
+<tr><th>Open milestones</th><td>{{.OpenMilestoneCount}}</td></tr>
+{{with .BypassableRulesets}}<tr><th>Rulesets with bypass actors</th><td class="no">{{join .}}</td></tr>
+{{end}}{{with .OrphanedChecks}}<tr><th>Potentially orphaned checks</th><td class="no">{{join .}}</td></tr>
+{{end}}{{with .RuleTypeBreakdown}}<tr><th>Rule types</th><td>{{ruleTypes .}}</td></tr>
+{{end}}</table>
+{{end}}{{end}}
+{{if include "settings"}}{{with .RepoSettings}}
+<h2>Repository Settings</h2>
+<table>
+<tr><th>Visibility</th><td>{{visibility .}}</td></tr>
+<tr><th>Archived</th><td>{{yesno .Archived}}</td></tr>
+{{if .Empty}}<tr><th>Empty</th><td>yes, no commits yet</td></tr>
+{{end}}{{if .Fork}}<tr><th>Fork of</th><td>{{.Parent}}{{if and .Source (ne .Source .Parent)}} (network root {{.Source}}){{end}}</td></tr>
+{{end}}<tr><th>Default branch</th><td><code>{{.DefaultBranch}}</code></td></tr>
+{{with .CreatedAt}}<tr><th>Created</th><td>{{.}}</td></tr>
+{{end}}{{with .PushedAt}}<tr><th>Last push</th><td>{{.}}</td></tr>
+{{end}}<tr><th>Issues</th><td>{{yesno .HasIssues}}</td></tr>
+<tr><th>Projects</th><td>{{yesno .HasProjects}}</td></tr>
+<tr><th>Wiki</th><td>{{yesno .HasWiki}}</td></tr>
+<tr><th>Allow merge commit</th><td>{{yesno .AllowMergeCommit}}</td></tr>
+<tr><th>Allow squash merge</th><td>{{yesno .AllowSquashMerge}}</td></tr>
+<tr><th>Allow rebase merge</th><td>{{yesno .AllowRebaseMerge}}</td></tr>
+<tr><th>Delete branch on merge</th><td>{{yesno .DeleteBranchOnMerge}}</td></tr>
+{{with .SquashMergeCommitTitle}}<tr><th>Squash merge commit title</th><td><code>{{.}}</code></td></tr>
+{{end}}{{with .SquashMergeCommitMessage}}<tr><th>Squash merge commit message</th><td><code>{{.}}</code></td></tr>
+{{end}}{{with .MergeCommitTitle}}<tr><th>Merge commit title</th><td><code>{{.}}</code></td></tr>
+{{end}}{{with .MergeCommitMessage}}<tr><th>Merge commit message</th><td><code>{{.}}</code></td></tr>
+{{end}}<tr><th>Topics</th><td>{{if .Topics}}{{join .Topics}}{{else}}<span class="muted">None</span>{{end}}</td></tr>
+{{range .CustomProperties}}<tr><th>Property: {{.Key}}</th><td>{{.Value}}</td></tr>
+{{end}}</table>
+{{end}}{{end}}
+{{if include "security"}}{{with .SecuritySettings}}
+<h2>Security Settings</h2>
+<table>
+<tr><th>Vulnerability alerts</th><td>{{yesno .VulnerabilityAlerts}}{{source . "vulnerability_alerts"}}</td></tr>
+<tr><th>Automated security fixes</th><td>{{yesno .AutomatedSecurityFixes}}{{source . "automated_security_fixes"}}</td></tr>
+<tr><th>Secret scanning</th><td>{{yesno .SecretScanning}}{{source . "secret_scanning"}}</td></tr>
+<tr><th>Push protection</th><td>{{yesno .SecretScanningPushProtection}}{{source . "secret_scanning_push_protection"}}</td></tr>
+<tr><th>Dependency graph</th><td>{{yesno .DependencyGraphEnabled}}{{source . "dependency_graph_enabled"}}</td></tr>
+{{with .SecretScanningAlertCount}}<tr><th>Open secret scanning alerts</th><td>{{alerts .}}</td></tr>
+{{end}}{{with .DependabotAlertCount}}<tr><th>Open Dependabot alerts</th><td>{{alerts .}}</td></tr>
+{{end}}</table>
+{{end}}{{end}}
+{{if and .Rulesets (include "rulesets")}}
+<h2>Rulesets ({{len .Rulesets}})</h2>
+<table>
+<tr><th>Name</th><th>Applies to</th><th>Enforcement</th><th>Source</th><th>Enforce admins</th><th>PR reviews</th><th>Approvals</th><th>Code owners</th><th>Linear history</th><th>Signed commits</th><th>Force pushes</th><th>Deletions</th><th>Merge queue</th><th>Status checks</th><th>Bypass actors</th></tr>
+{{range .Rulesets}}<tr><td>{{.Name}}</td><td>{{target .}}</td><td>{{.Enforcement}}</td><td>{{.Source}}</td><td>{{yesno .EnforceAdmins}}</td><td>{{yesno .RequiredPullRequestReviews}}</td><td>{{.RequiredApprovingReviewCount}}</td><td>{{yesno .RequireCodeOwnerReviews}}</td><td>{{yesno .RequiredLinearHistory}}</td><td>{{yesno .RequireSignedCommits}}</td><td>{{yesno .AllowForcePushes}}</td><td>{{yesno .AllowDeletions}}</td><td>{{yesno .MergeQueueEnabled}}{{with .MergeQueue}} {{.MergeMethod}} ({{.MinEntriesToMerge}}-{{.MaxEntriesToMerge}}){{end}}</td><td>{{join .RequiredStatusChecks}}</td><td>{{join (actors .BypassActors)}}</td></tr>
+{{end}}</table>
+{{end}}
+{{if and .ProtectedBranches (include "branch-protection")}}
+<h2>Branch Protection ({{len .ProtectedBranches}})</h2>
+<table>
+<tr><th>Branch</th><th>Required reviews</th><th>Enforce admins</th><th>Signed commits</th><th>Push restrictions</th></tr>
+{{range .ProtectedBranches}}<tr><td><code>{{.Name}}</code></td><td>{{.RequiredReviews}}</td><td>{{yesno .EnforceAdmins}}</td><td>{{yesno .RequireSignedCommits}}</td><td>{{join .Restrictions}}</td></tr>
+{{end}}</table>
+{{end}}
+{{if and .Collaborators (include "collaborators")}}
+<h2>Collaborators ({{len .Collaborators}})</h2>
+<table>
+<tr><th>Login</th><th>Permission</th><th>Type</th><th>Role</th><th>Source</th><th>Last commit</th><th>Inactive</th></tr>
+{{range .Collaborators}}<tr><td>{{.Login}}</td><td>{{.Permission}}</td><td>{{.Type}}</td><td>{{.RoleName}}</td><td>{{.Source}}</td><td>{{.LastCommitDate}}</td><td>{{yesno .Inactive}}</td></tr>
+{{end}}</table>
+{{end}}
+{{if and .Teams (include "teams")}}
+<h2>Teams ({{len .Teams}})</h2>
+<table>
+<tr><th>Name</th><th>Slug</th><th>Permission</th></tr>
+{{range .Teams}}<tr><td>{{.Name}}</td><td>{{.Slug}}</td><td>{{.Permission}}</td></tr>
+{{end}}</table>
+{{end}}
+{{if and .IssueLabels (include "labels")}}
+<h2>Issue Labels ({{len .IssueLabels}})</h2>
+<table>
+<tr><th>Name</th><th>Color</th><th>Description</th></tr>
+{{range .IssueLabels}}<tr><td>{{.Name}}</td><td><code>#{{.Color}}</code></td><td>{{.Description}}</td></tr>
+{{end}}</table>
+{{end}}
+{{if and .Milestones (include "milestones")}}
+<h2>Milestones ({{len .Milestones}})</h2>
+<table>
+<tr><th>Title</th><th>State</th><th>Due</th><th>Description</th></tr>
+{{range .Milestones}}<tr><td>{{.Title}}</td><td>{{.State}}</td><td>{{.DueOn}}</td><td>{{.Description}}</td></tr>
+{{end}}</table>
+{{end}}
+{{if and .Webhooks (include "webhooks")}}
+<h2>Webhooks ({{len .Webhooks}})</h2>
+<table>
+<tr><th>URL</th><th>Events</th><th>Active</th><th>Content type</th><th>Secret</th></tr>
+{{range .Webhooks}}<tr><td>{{.URL}}</td><td>{{join .Events}}</td><td>{{yesno .Active}}</td><td>{{.ContentType}}</td><td>{{yesno .Secret}}</td></tr>
+{{end}}</table>
+{{end}}
+{{if and .Environments (include "environments")}}
+<h2>Environments ({{len .Environments}})</h2>
+<table>
+<tr><th>Name</th><th>Wait timer</th><th>Reviewers</th><th>Branch policy</th></tr>
+{{range .Environments}}<tr><td>{{.Name}}</td><td>{{.WaitTimer}} min</td><td>{{join .Reviewers}}</td><td>{{.DeploymentBranchPolicy}}</td></tr>
+{{end}}</table>
+{{end}}
+{{if and .DeployKeys (include "deploy-keys")}}
+<h2>Deploy Keys ({{len .DeployKeys}})</h2>
+<table>
+<tr><th>Title</th><th>Read-only</th><th>Created</th><th>Last used</th></tr>
+{{range .DeployKeys}}<tr><td>{{.Title}}</td><td>{{yesno .ReadOnly}}</td><td>{{.CreatedAt}}</td><td>{{.LastUsed}}</td></tr>
+{{end}}</table>
+{{end}}
+{{if and .Actions (include "actions")}}{{with .Actions}}
+<h2>Actions</h2>
+<table>
+<tr><th>Kind</th><th>Name</th><th>Value</th></tr>
+{{range .SecretNames}}<tr><td>Secret</td><td>{{.}}</td><td class="muted">hidden</td></tr>
+{{end}}{{range .Variables}}<tr><td>Variable</td><td>{{.Key}}</td><td>{{.Value}}</td></tr>
+{{end}}</table>
+{{end}}{{end}}
+{{if and .CodeOwners (include "codeowners")}}{{with .CodeOwners}}
+<h2>CODEOWNERS</h2>
+{{if .Exists}}<p>Found at <code>{{.Path}}</code> with {{.RuleCount}} rule(s).</p>
+<table>
+<tr><th>Pattern</th><th>Owners</th></tr>
+{{range .Entries}}<tr><td><code>{{.Pattern}}</code></td><td>{{join .Owners}}</td></tr>
+{{end}}</table>
+{{range .Errors}}<p class="no">{{.}}</p>
+{{end}}{{else}}<p class="muted">No CODEOWNERS file found.</p>{{end}}
+{{end}}{{end}}
+{{if and .Pages (include "pages")}}{{with .Pages}}
+<h2>GitHub Pages</h2>
+{{if .Enabled}}<table>
+<tr><th>Source</th><td><code>{{.SourceBranch}}</code> {{.SourcePath}}</td></tr>
+<tr><th>Custom domain</th><td>{{.CustomDomain}}</td></tr>
+<tr><th>HTTPS enforced</th><td>{{yesno .HTTPSEnforced}}</td></tr>
+<tr><th>Public</th><td>{{yesno .Public}}</td></tr>
+</table>{{else}}<p class="muted">GitHub Pages is not enabled.</p>{{end}}
+{{end}}{{end}}
+{{if and .Autolinks (include "autolinks")}}
+<h2>Autolinks ({{len .Autolinks}})</h2>
+<table>
+<tr><th>Key prefix</th><th>URL template</th><th>Alphanumeric</th></tr>
+{{range .Autolinks}}<tr><td><code>{{.KeyPrefix}}</code></td><td>{{.URLTemplate}}</td><td>{{yesno .IsAlphanumeric}}</td></tr>
+{{end}}</table>
+{{end}}
+{{if and .Workflows (include "workflows")}}{{with .Workflows}}
+<h2>Workflows ({{len .Workflows}})</h2>
+<p>Actions enabled: {{if .ActionsEnabled}}{{yesno (deref .ActionsEnabled)}}{{else}}<span class="muted">unknown</span>{{end}}</p>
+{{if .Workflows}}<table>
+<tr><th>Name</th><th>Path</th><th>State</th></tr>
+{{range .Workflows}}<tr><td>{{.Name}}</td><td><code>{{.Path}}</code></td><td>{{.State}}</td></tr>
+{{end}}</table>{{end}}
+{{end}}{{end}}
+{{if and .Dependabot (include "dependabot")}}{{with .Dependabot}}
+<h2>Dependabot</h2>
+{{if .Exists}}<p>Found at <code>{{.Path}}</code>.</p>
+{{if .Ecosystems}}{{$schedules := .Schedules}}<table>
+<tr><th>Ecosystem</th><th>Schedule</th></tr>
+{{range $i, $ecosystem := .Ecosystems}}<tr><td>{{$ecosystem}}</td><td>{{index $schedules $i}}</td></tr>
+{{end}}</table>{{end}}{{else}}<p class="muted">No Dependabot configuration found.</p>{{end}}
+{{end}}{{end}}
+{{if and (or .Releases .TagProtections) (include "releases")}}
+<h2>Releases ({{len .Releases}})</h2>
+{{if .Releases}}<table>
+<tr><th>Tag</th><th>Name</th><th>Draft</th><th>Prerelease</th><th>Published</th></tr>
+{{range .Releases}}<tr><td><code>{{.TagName}}</code></td><td>{{.Name}}</td><td>{{yesno .Draft}}</td><td>{{yesno .Prerelease}}</td><td>{{.PublishedAt}}</td></tr>
+{{end}}</table>{{end}}
+{{if .TagProtections}}<p>Protected tag patterns: {{range $i, $protection := .TagProtections}}{{if $i}}, {{end}}<code>{{$protection.Pattern}}</code>{{end}}</p>{{end}}
+{{end}}
+{{if and .Templates (include "templates")}}{{with .Templates}}
+<h2>Issue &amp; PR Templates</h2>
+<table>
+<tr><th>PR template</th><td>{{yesno .HasPRTemplate}}</td></tr>
+<tr><th>Template chooser config</th><td>{{yesno .HasConfigYML}}</td></tr>
+<tr><th>Issue templates</th><td>{{range .IssueTemplates}}<code>{{.}}</code><br>{{else}}<span class="muted">None</span>{{end}}</td></tr>
+</table>
+{{end}}{{end}}
+{{if and .Branches (include "branches")}}
+<h2>Branches ({{len .Branches}})</h2>
+<table>
+<tr><th>Branch</th><th>Protected</th><th>Ahead</th><th>Behind</th><th>Last commit</th><th>Stale</th></tr>
+{{range .Branches}}<tr><td><code>{{.Name}}</code></td><td>{{yesno .Protected}}</td><td>{{.AheadBy}}</td><td>{{.BehindBy}}</td><td>{{.LastCommitDate}}</td><td>{{yesno .Stale}}</td></tr>
+{{end}}</table>
+{{end}}
+{{if and .TagRules (include "tag-rules")}}
+<h2>Tag rulesets ({{len .TagRules}})</h2>
+<table>
+<tr><th>Name</th><th>Pattern</th><th>Enforcement</th></tr>
+{{range .TagRules}}<tr><td>{{.Name}}</td><td><code>{{.Pattern}}</code></td><td>{{.Enforcement}}</td></tr>
+{{end}}</table>
+{{end}}
+{{if and .CommunityFiles (include "community-files")}}{{with .CommunityFiles}}
+<h2>Community files</h2>
+<table>
+<tr><th>Health</th><td>{{.HealthPercentage}}%</td></tr>
+<tr><th>License</th><td>{{yesno .HasLicense}}{{with .License}} <code>{{.}}</code>{{end}}</td></tr>
+<tr><th>Contributing</th><td>{{yesno .HasContributing}}</td></tr>
+<tr><th>Code of conduct</th><td>{{yesno .HasCodeOfConduct}}</td></tr>
+<tr><th>Security policy</th><td>{{yesno .HasSecurityPolicy}}</td></tr>
+<tr><th>README</th><td>{{yesno .HasReadme}}</td></tr>
+</table>
+{{end}}{{end}}
+{{if and .RecentEvents (include "recent-events")}}
+<h2>Recent events ({{len .RecentEvents}})</h2>
+<table>
+<tr><th>Date</th><th>Event</th><th>Actor</th><th>Detail</th></tr>
+{{range .RecentEvents}}<tr><td>{{.CreatedAt}}</td><td>{{.Type}}</td><td>@{{.Actor}}</td><td>{{.Detail}}</td></tr>
+{{end}}</table>
+{{end}}
+{{end}}
+</body>
+</html>
+`
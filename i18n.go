@@ -0,0 +1,53 @@
+package main
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// Translations of the table output labels, one file per language keyed by
+// the English label. en.json lists every translatable label.
+//
+//go:embed i18n/*.json
+var catalogFiles embed.FS
+
+const defaultLang = "en"
+
+// messages is the catalog selected by --lang
+var messages map[string]string
+
+// loadCatalog reads the embedded catalog for lang
+func loadCatalog(lang string) (map[string]string, error) {
+	data, err := catalogFiles.ReadFile("i18n/" + lang + ".json")
+	if err != nil {
+		return nil, err
+	}
+	var catalog map[string]string
+	if err := json.Unmarshal(data, &catalog); err != nil {
+		return nil, fmt.Errorf("invalid %s catalog: %v", lang, err)
+	}
+	return catalog, nil
+}
+
+// configureLanguage selects the --lang catalog, falling back to English when
+// there is no translation for it
+func configureLanguage() {
+	lang = strings.ToLower(lang)
+	catalog, err := loadCatalog(lang)
+	if err != nil {
+		logger.Info(fmt.Sprintf("no translation for --lang %q, using %s", lang, defaultLang))
+		catalog, _ = loadCatalog(defaultLang)
+	}
+	messages = catalog
+}
+
+// msg translates a table label, returning it unchanged when the catalog has
+// no entry for it
+func msg(label string) string {
+	if translated, ok := messages[label]; ok {
+		return translated
+	}
+	return label
+}
@@ -0,0 +1,206 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"os"
+	"strings"
+)
+
+// Verbosity levels selected by repeating -v
+const (
+	verboseProgress = 1
+	verboseRequests = 2
+	verboseHeaders  = 3
+)
+
+// levelTrace is the slog level of the -vvv response dumps
+const levelTrace = slog.LevelDebug - 4
+
+// levelNotice sits between Warn and Error for the findings shown without -v,
+// such as policy violations, while failures logged at Warn need -v
+const levelNotice = slog.LevelWarn + 2
+
+// logger receives every diagnostic. By default it only writes the message
+// to stderr; configureLogging adds the structured --log-file.
+var logger = slog.New(stderrHandler{})
+
+// verbosityLevel maps the -v count to the lowest slog level shown: notices
+// by default, then Info, Debug and levelTrace for -v, -vv and -vvv
+func verbosityLevel() slog.Level {
+	switch {
+	case verbosity >= verboseHeaders:
+		return levelTrace
+	case verbosity >= verboseRequests:
+		return slog.LevelDebug
+	case verbosity >= verboseProgress:
+		return slog.LevelInfo
+	default:
+		return levelNotice
+	}
+}
+
+// logNotice logs a finding that is shown by default
+func logNotice(message string, args ...any) {
+	logger.Log(context.Background(), levelNotice, message, args...)
+}
+
+// stderrHandler prints just the message of each entry at or above the
+// verbosity level, keeping stderr as readable as plain prints. --quiet
+// leaves only errors. Attributes are for the log file and are dropped here.
+type stderrHandler struct{}
+
+func (stderrHandler) Enabled(_ context.Context, level slog.Level) bool {
+	if quiet {
+		return level >= slog.LevelError
+	}
+	return level >= verbosityLevel()
+}
+
+func (stderrHandler) Handle(_ context.Context, record slog.Record) error {
+	_, err := fmt.Fprintln(os.Stderr, record.Message)
+	return err
+}
+
+func (h stderrHandler) WithAttrs([]slog.Attr) slog.Handler { return h }
+func (h stderrHandler) WithGroup(string) slog.Handler      { return h }
+
+// teeHandler passes each entry to every handler that is enabled for it
+type teeHandler []slog.Handler
+
+func (t teeHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	for _, handler := range t {
+		if handler.Enabled(ctx, level) {
+			return true
+		}
+	}
+	return false
+}
+
+func (t teeHandler) Handle(ctx context.Context, record slog.Record) error {
+	for _, handler := range t {
+		if !handler.Enabled(ctx, record.Level) {
+			continue
+		}
+		if err := handler.Handle(ctx, record.Clone()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (t teeHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	handlers := make(teeHandler, 0, len(t))
+	for _, handler := range t {
+		handlers = append(handlers, handler.WithAttrs(attrs))
+	}
+	return handlers
+}
+
+func (t teeHandler) WithGroup(name string) slog.Handler {
+	handlers := make(teeHandler, 0, len(t))
+	for _, handler := range t {
+		handlers = append(handlers, handler.WithGroup(name))
+	}
+	return handlers
+}
+
+// configureLogging opens --log-file and tees the diagnostics into it as
+// --log-format entries. The file records progress even without -v so
+// unattended scans leave a trail, and follows -vv and -vvv beyond that.
+// The report itself is only ever written to stdout or --output.
+func configureLogging() error {
+	if logFile == "" {
+		return nil
+	}
+	if outputFile != "" && logFile == outputFile {
+		return fmt.Errorf("--log-file must differ from --output")
+	}
+
+	level := slog.LevelInfo
+	if verbosityLevel() < level {
+		level = verbosityLevel()
+	}
+
+	file, err := os.OpenFile(logFile, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return fmt.Errorf("failed to open log file: %v", err)
+	}
+
+	options := &slog.HandlerOptions{Level: level, ReplaceAttr: customLevelNames}
+	var handler slog.Handler
+	switch strings.ToLower(logFormat) {
+	case "text":
+		handler = slog.NewTextHandler(file, options)
+	case "json":
+		handler = slog.NewJSONHandler(file, options)
+	default:
+		file.Close()
+		return fmt.Errorf("unsupported --log-format: %s (use text or json)", logFormat)
+	}
+
+	logger = slog.New(teeHandler{stderrHandler{}, handler})
+	return nil
+}
+
+// customLevelNames names levelTrace TRACE and levelNotice NOTICE instead of
+// slog's DEBUG-4 and WARN+2
+func customLevelNames(groups []string, attr slog.Attr) slog.Attr {
+	if attr.Key == slog.LevelKey && len(groups) == 0 {
+		if level, ok := attr.Value.Any().(slog.Level); ok {
+			switch level {
+			case levelTrace:
+				attr.Value = slog.StringValue("TRACE")
+			case levelNotice:
+				attr.Value = slog.StringValue("NOTICE")
+			}
+		}
+	}
+	return attr
+}
+
+// repoAttr labels an entry with the repository it is about
+func repoAttr(owner, repo string) slog.Attr {
+	return slog.String("repo", owner+"/"+repo)
+}
+
+// rateLimitHeaders are echoed for every response at verboseHeaders
+var rateLimitHeaders = []string{"X-RateLimit-Limit", "X-RateLimit-Remaining", "X-RateLimit-Used", "X-RateLimit-Reset", "X-RateLimit-Resource", "Retry-After"}
+
+// loggingTransport logs requests and responses according to the verbosity
+// level
+type loggingTransport struct {
+	next http.RoundTripper
+}
+
+// newLoggingTransport returns nil below verboseRequests so go-gh keeps its
+// default transport
+func newLoggingTransport() http.RoundTripper {
+	if verbosity < verboseRequests {
+		return nil
+	}
+	return &loggingTransport{next: http.DefaultTransport}
+}
+
+func (t *loggingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	logger.Debug(fmt.Sprintf("> %s %s", req.Method, req.URL))
+	if version := req.Header.Get(apiVersionHeader); version != "" {
+		logger.Debug(fmt.Sprintf("> %s: %s", apiVersionHeader, version))
+	}
+
+	resp, err := t.next.RoundTrip(req)
+	if err != nil || verbosity < verboseHeaders {
+		return resp, err
+	}
+
+	logger.Log(req.Context(), levelTrace, fmt.Sprintf("< %s", resp.Status))
+	for _, header := range rateLimitHeaders {
+		if value := resp.Header.Get(header); value != "" {
+			logger.Log(req.Context(), levelTrace, fmt.Sprintf("< %s: %s", header, value))
+		}
+	}
+
+	return resp, nil
+}
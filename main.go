@@ -1,100 +1,449 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"io"
+	"log/slog"
 	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"sort"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/cli/go-gh/v2/pkg/api"
+	"github.com/cli/go-gh/v2/pkg/repository"
+	"github.com/cli/go-gh/v2/pkg/term"
 	"github.com/jefeish/gh-repo-inspect/utils"
 	"github.com/spf13/cobra"
 )
 
 type RepoInfo struct {
-	Owner string `json:"owner"`
-	Name  string `json:"name"`
+	Owner string `json:"owner" xml:"owner"`
+	Name  string `json:"name" xml:"name"`
 }
 
 type GovernanceConfig struct {
-	Repository       RepoInfo           `json:"repository"`
-	Rulesets         []Ruleset          `json:"rulesets,omitempty"`
-	RequiredChecks   []string           `json:"required_checks,omitempty"`
-	Collaborators    []Collaborator     `json:"collaborators,omitempty"`
-	Teams            []Team             `json:"teams,omitempty"`
-	SecuritySettings SecuritySettings   `json:"security_settings"`
-	RepoSettings     RepositorySettings `json:"repository_settings"`
-	IssueLabels      []Label            `json:"issue_labels,omitempty"`
-	Milestones       []Milestone        `json:"milestones,omitempty"`
+	Repository        RepoInfo           `json:"repository" xml:"repository"`
+	Summary           *Summary           `json:"summary,omitempty" xml:"summary,omitempty"`
+	Rulesets          []Ruleset          `json:"rulesets,omitempty" xml:"ruleset,omitempty"`
+	RequiredChecks    []string           `json:"required_checks,omitempty" xml:"required_check,omitempty"`
+	Collaborators     []Collaborator     `json:"collaborators,omitempty" xml:"collaborator,omitempty"`
+	Teams             []Team             `json:"teams,omitempty" xml:"team,omitempty"`
+	SecuritySettings  SecuritySettings   `json:"security_settings" xml:"security_settings"`
+	RepoSettings      RepositorySettings `json:"repository_settings" xml:"repository_settings"`
+	IssueLabels       []Label            `json:"issue_labels,omitempty" xml:"issue_label,omitempty"`
+	Milestones        []Milestone        `json:"milestones,omitempty" xml:"milestone,omitempty"`
+	Webhooks          []Webhook          `json:"webhooks,omitempty" xml:"webhook,omitempty"`
+	Environments      []Environment      `json:"environments,omitempty" xml:"environment,omitempty"`
+	ProtectedBranches []ProtectedBranch  `json:"protected_branches,omitempty" xml:"protected_branch,omitempty"`
+	DeployKeys        []DeployKey        `json:"deploy_keys,omitempty" xml:"deploy_key,omitempty"`
+	Actions           *ActionsConfig     `json:"actions,omitempty" xml:"actions,omitempty"`
+	CodeOwners        *CodeOwners        `json:"codeowners,omitempty" xml:"codeowners,omitempty"`
+	Pages             *PagesConfig       `json:"pages,omitempty" xml:"pages,omitempty"`
+	Autolinks         []Autolink         `json:"autolinks,omitempty" xml:"autolink,omitempty"`
+	Workflows         *WorkflowsConfig   `json:"workflows,omitempty" xml:"workflows,omitempty"`
+	Dependabot        *Dependabot        `json:"dependabot,omitempty" xml:"dependabot,omitempty"`
+	Releases          []Release          `json:"releases,omitempty" xml:"release,omitempty"`
+	TagProtections    []TagProtection    `json:"tag_protections,omitempty" xml:"tag_protection,omitempty"`
+	Templates         *Templates         `json:"templates,omitempty" xml:"templates,omitempty"`
+	Branches          []Branch           `json:"branches,omitempty" xml:"branch,omitempty"`
+	TagRules          []TagRule          `json:"tag_rules,omitempty" xml:"tag_rule,omitempty"`
+	CommunityFiles    *CommunityProfile  `json:"community_files,omitempty" xml:"community_files,omitempty"`
+	RecentEvents      []Event            `json:"recent_events,omitempty" xml:"recent_event,omitempty"`
+	Violations        []Violation        `json:"violations,omitempty" yaml:"-" xml:"violation,omitempty"`
+}
+
+type Summary struct {
+	ProtectedBranchCount int  `json:"protected_branch_count" xml:"protected_branch_count"`
+	RulesetCount         int  `json:"ruleset_count" xml:"ruleset_count"`
+	HasSecretScanning    bool `json:"has_secret_scanning" xml:"has_secret_scanning"`
+	CollaboratorCount    int  `json:"collaborator_count" xml:"collaborator_count"`
+	AdminCount           int  `json:"admin_count" xml:"admin_count"`
+	OpenMilestoneCount   int  `json:"open_milestone_count" xml:"open_milestone_count"`
+	RiskScore            int  `json:"risk_score" xml:"risk_score"`
+	// BypassableRulesets names the rulesets with bypass actors, which
+	// weaken the enforcement the ruleset otherwise guarantees
+	BypassableRulesets []string `json:"bypassable_rulesets,omitempty" xml:"bypassable_ruleset,omitempty"`
+	// OrphanedChecks lists the potentially orphaned required checks of the
+	// active rulesets
+	OrphanedChecks []string `json:"orphaned_checks,omitempty" xml:"orphaned_check,omitempty"`
+	// DefaultBranchProtected is true when an active ruleset or branch
+	// protection rule matches the default branch
+	DefaultBranchProtected bool `json:"default_branch_protected" xml:"default_branch_protected"`
+	// RuleTypeBreakdown counts the active rulesets enforcing each rule type
+	RuleTypeBreakdown ruleTypeCounts `json:"rule_type_breakdown,omitempty" xml:"rule_type_breakdown,omitempty"`
 }
 
 type Ruleset struct {
-	Name                           string   `json:"name"`
-	Pattern                        string   `json:"pattern"`
-	EnforceAdmins                  bool     `json:"enforce_admins"`
-	RequiredStatusChecks           []string `json:"required_status_checks,omitempty"`
-	RequiredPullRequestReviews     bool     `json:"required_pull_request_reviews"`
-	RequiredApprovingReviewCount   int      `json:"required_approving_review_count"`
-	DismissStaleReviews            bool     `json:"dismiss_stale_reviews"`
-	RequireCodeOwnerReviews        bool     `json:"require_code_owner_reviews"`
-	RequiredLinearHistory          bool     `json:"required_linear_history"`
-	AllowForcePushes               bool     `json:"allow_force_pushes"`
-	AllowDeletions                 bool     `json:"allow_deletions"`
-	RequiredConversationResolution bool     `json:"required_conversation_resolution"`
+	Name string `json:"name" xml:"name"`
+	// Pattern is the first included ref name (or the protected branch's
+	// name); RefNameInclude and RefNameExclude hold the full conditions
+	Pattern                        string      `json:"pattern" xml:"pattern"`
+	Enforcement                    string      `json:"enforcement,omitempty" xml:"enforcement,omitempty"`
+	Source                         string      `json:"source,omitempty" xml:"source,omitempty"`
+	EnforceAdmins                  bool        `json:"enforce_admins" xml:"enforce_admins"`
+	RequiredStatusChecks           []string    `json:"required_status_checks,omitempty" xml:"required_status_check,omitempty"`
+	RequiredPullRequestReviews     bool        `json:"required_pull_request_reviews" xml:"required_pull_request_reviews"`
+	RequiredApprovingReviewCount   int         `json:"required_approving_review_count" xml:"required_approving_review_count"`
+	DismissStaleReviews            bool        `json:"dismiss_stale_reviews" xml:"dismiss_stale_reviews"`
+	RequireCodeOwnerReviews        bool        `json:"require_code_owner_reviews" xml:"require_code_owner_reviews"`
+	RequiredLinearHistory          bool        `json:"required_linear_history" xml:"required_linear_history"`
+	RequireSignedCommits           bool        `json:"require_signed_commits" xml:"require_signed_commits"`
+	AllowForcePushes               bool        `json:"allow_force_pushes" xml:"allow_force_pushes"`
+	AllowDeletions                 bool        `json:"allow_deletions" xml:"allow_deletions"`
+	RequiredConversationResolution bool        `json:"required_conversation_resolution" xml:"required_conversation_resolution"`
+	MergeQueueEnabled              bool        `json:"merge_queue_enabled" xml:"merge_queue_enabled"`
+	MergeQueue                     *MergeQueue `json:"merge_queue,omitempty" xml:"merge_queue,omitempty"`
+	// RuleTypes are the REST types of the ruleset's rules, in API order;
+	// empty for classic branch protection
+	RuleTypes      []string      `json:"rule_types,omitempty" xml:"rule_type,omitempty"`
+	RefNameInclude []string      `json:"ref_name_include,omitempty" xml:"ref_name_include,omitempty"`
+	RefNameExclude []string      `json:"ref_name_exclude,omitempty" xml:"ref_name_exclude,omitempty"`
+	BypassActors   []BypassActor `json:"bypass_actors,omitempty" xml:"bypass_actor,omitempty"`
+	// OrphanedChecks are the required status checks with no matching run
+	// on the default branch or recent pull requests, which may no longer
+	// be produced by any workflow and then block every merge
+	OrphanedChecks []string `json:"orphaned_checks,omitempty" xml:"orphaned_check,omitempty"`
+	// RFC3339 timestamps of the ruleset, empty for classic branch protection
+	CreatedAt string `json:"created_at,omitempty" xml:"created_at,omitempty"`
+	UpdatedAt string `json:"updated_at,omitempty" xml:"updated_at,omitempty"`
+}
+
+// BypassActor is someone who may bypass a ruleset. Type is the REST actor
+// type ("Team", "Integration", "RepositoryRole", "OrganizationAdmin" or
+// "DeployKey") and Mode is "always", "pull_request" or "exempt".
+type BypassActor struct {
+	Type string `json:"type" xml:"type"`
+	Name string `json:"name" xml:"name"`
+	Mode string `json:"mode" xml:"mode"`
+}
+
+// MergeQueue is the configuration of a ruleset's merge_queue rule.
+// MergeMethod is "MERGE", "SQUASH" or "REBASE".
+type MergeQueue struct {
+	MergeMethod       string `json:"merge_method" xml:"merge_method"`
+	MinEntriesToMerge int    `json:"min_entries_to_merge" xml:"min_entries_to_merge"`
+	MaxEntriesToMerge int    `json:"max_entries_to_merge" xml:"max_entries_to_merge"`
 }
 
 type Collaborator struct {
-	Login      string `json:"login"`
-	Permission string `json:"permission"`
-	Type       string `json:"type"`
+	Login      string `json:"login" xml:"login"`
+	Permission string `json:"permission" xml:"permission"`
+	Type       string `json:"type" xml:"type"`
+	Source     string `json:"source,omitempty" xml:"source,omitempty"`
+	RoleName   string `json:"role_name,omitempty" xml:"role_name,omitempty"`
+	// LastCommitDate and Inactive are only set with --with-activity, and
+	// Inactive only with --inactive-days
+	LastCommitDate string `json:"last_commit_date,omitempty" xml:"last_commit_date,omitempty"`
+	Inactive       bool   `json:"inactive,omitempty" xml:"inactive,omitempty"`
 }
 
 type Team struct {
-	Name       string `json:"name"`
-	Slug       string `json:"slug"`
-	Permission string `json:"permission"`
+	Name       string `json:"name" xml:"name"`
+	Slug       string `json:"slug" xml:"slug"`
+	Permission string `json:"permission" xml:"permission"`
 }
 
 type SecuritySettings struct {
-	VulnerabilityAlerts          bool `json:"vulnerability_alerts"`
-	AutomatedSecurityFixes       bool `json:"automated_security_fixes"`
-	SecretScanning               bool `json:"secret_scanning"`
-	SecretScanningPushProtection bool `json:"secret_scanning_push_protection"`
-	DependencyGraphEnabled       bool `json:"dependency_graph_enabled"`
+	VulnerabilityAlerts          bool `json:"vulnerability_alerts" xml:"vulnerability_alerts"`
+	AutomatedSecurityFixes       bool `json:"automated_security_fixes" xml:"automated_security_fixes"`
+	SecretScanning               bool `json:"secret_scanning" xml:"secret_scanning"`
+	SecretScanningPushProtection bool `json:"secret_scanning_push_protection" xml:"secret_scanning_push_protection"`
+	DependencyGraphEnabled       bool `json:"dependency_graph_enabled" xml:"dependency_graph_enabled"`
+	// Sources maps each setting's JSON name to where its value came from:
+	// sourceRepo, sourceOrgEnforced or sourceUnknown
+	Sources settingSources `json:"sources,omitempty" xml:"sources,omitempty"`
+	// Open alert counts, only set with --with-alerts; -1 when the token
+	// can't read the alerts or the feature is off
+	SecretScanningAlertCount *int `json:"secret_scanning_alert_count,omitempty" xml:"secret_scanning_alert_count,omitempty"`
+	DependabotAlertCount     *int `json:"dependabot_alert_count,omitempty" xml:"dependabot_alert_count,omitempty"`
 }
 
+// Sources of a security setting's value
+const (
+	sourceRepo        = "repo"
+	sourceOrgEnforced = "org-enforced"
+	sourceUnknown     = "unknown"
+)
+
 type RepositorySettings struct {
-	Private             bool   `json:"private"`
-	Archived            bool   `json:"archived"`
-	Disabled            bool   `json:"disabled"`
-	DefaultBranch       string `json:"default_branch"`
-	AllowMergeCommit    bool   `json:"allow_merge_commit"`
-	AllowSquashMerge    bool   `json:"allow_squash_merge"`
-	AllowRebaseMerge    bool   `json:"allow_rebase_merge"`
-	AllowAutoMerge      bool   `json:"allow_auto_merge"`
-	DeleteBranchOnMerge bool   `json:"delete_branch_on_merge"`
-	HasIssues           bool   `json:"has_issues"`
-	HasProjects         bool   `json:"has_projects"`
-	HasWiki             bool   `json:"has_wiki"`
-	HasDownloads        bool   `json:"has_downloads"`
+	// Visibility is "public", "private" or "internal"; Private is also true
+	// for internal repositories and is kept for existing consumers
+	Visibility string `json:"visibility,omitempty" xml:"visibility,omitempty"`
+	Private    bool   `json:"private" xml:"private"`
+	Archived   bool   `json:"archived" xml:"archived"`
+	Disabled   bool   `json:"disabled" xml:"disabled"`
+	// Empty is true for repositories without any commits, whose
+	// branch-dependent sections are skipped
+	Empty               bool   `json:"empty" xml:"empty"`
+	DefaultBranch       string `json:"default_branch" xml:"default_branch"`
+	AllowMergeCommit    bool   `json:"allow_merge_commit" xml:"allow_merge_commit"`
+	AllowSquashMerge    bool   `json:"allow_squash_merge" xml:"allow_squash_merge"`
+	AllowRebaseMerge    bool   `json:"allow_rebase_merge" xml:"allow_rebase_merge"`
+	AllowAutoMerge      bool   `json:"allow_auto_merge" xml:"allow_auto_merge"`
+	DeleteBranchOnMerge bool   `json:"delete_branch_on_merge" xml:"delete_branch_on_merge"`
+	// Commit title/message defaults, set only for the allowed merge types
+	SquashMergeCommitTitle   string     `json:"squash_merge_commit_title,omitempty" xml:"squash_merge_commit_title,omitempty"`
+	SquashMergeCommitMessage string     `json:"squash_merge_commit_message,omitempty" xml:"squash_merge_commit_message,omitempty"`
+	MergeCommitTitle         string     `json:"merge_commit_title,omitempty" xml:"merge_commit_title,omitempty"`
+	MergeCommitMessage       string     `json:"merge_commit_message,omitempty" xml:"merge_commit_message,omitempty"`
+	HasIssues                bool       `json:"has_issues" xml:"has_issues"`
+	HasProjects              bool       `json:"has_projects" xml:"has_projects"`
+	HasWiki                  bool       `json:"has_wiki" xml:"has_wiki"`
+	HasDownloads             bool       `json:"has_downloads" xml:"has_downloads"`
+	Topics                   []string   `json:"topics,omitempty" xml:"topic,omitempty"`
+	CustomProperties         []KeyValue `json:"custom_properties,omitempty" xml:"custom_property,omitempty"`
+	CreatedAt                string     `json:"created_at,omitempty" xml:"created_at,omitempty"`
+	UpdatedAt                string     `json:"updated_at,omitempty" xml:"updated_at,omitempty"`
+	PushedAt                 string     `json:"pushed_at,omitempty" xml:"pushed_at,omitempty"`
+	// Size and activity metrics. Watchers counts subscribers since the API's
+	// watchers_count is the star count.
+	SizeKB     int    `json:"size_kb" xml:"size_kb"`
+	OpenIssues int    `json:"open_issues" xml:"open_issues"`
+	Forks      int    `json:"forks" xml:"forks"`
+	Stargazers int    `json:"stargazers" xml:"stargazers"`
+	Watchers   int    `json:"watchers" xml:"watchers"`
+	Language   string `json:"language,omitempty" xml:"language,omitempty"`
+	// Parent is the repository this one was forked from and Source the root
+	// of the fork network; both are empty for repositories that aren't forks
+	Fork   bool   `json:"fork" xml:"fork"`
+	Parent string `json:"parent,omitempty" xml:"parent,omitempty"`
+	Source string `json:"source,omitempty" xml:"source,omitempty"`
 }
 
 type Label struct {
-	Name        string `json:"name"`
-	Color       string `json:"color"`
-	Description string `json:"description,omitempty"`
+	Name        string `json:"name" xml:"name"`
+	Color       string `json:"color" xml:"color"`
+	Description string `json:"description,omitempty" xml:"description,omitempty"`
 }
 
 type Milestone struct {
-	Title       string `json:"title"`
-	Description string `json:"description,omitempty"`
-	State       string `json:"state"`
-	DueOn       string `json:"due_on,omitempty"`
+	Title       string `json:"title" xml:"title"`
+	Description string `json:"description,omitempty" xml:"description,omitempty"`
+	State       string `json:"state" xml:"state"`
+	DueOn       string `json:"due_on,omitempty" xml:"due_on,omitempty"`
+	UpdatedAt   string `json:"updated_at,omitempty" xml:"updated_at,omitempty"`
+}
+
+type Webhook struct {
+	ID          int      `json:"id" xml:"id"`
+	URL         string   `json:"url" xml:"url"`
+	Events      []string `json:"events" xml:"event"`
+	Active      bool     `json:"active" xml:"active"`
+	ContentType string   `json:"content_type" xml:"content_type"`
+	Secret      bool     `json:"secret" xml:"secret"`
+}
+
+type Environment struct {
+	Name                   string   `json:"name" xml:"name"`
+	WaitTimer              int      `json:"wait_timer" xml:"wait_timer"`
+	Reviewers              []string `json:"reviewers,omitempty" xml:"reviewer,omitempty"`
+	DeploymentBranchPolicy string   `json:"deployment_branch_policy" xml:"deployment_branch_policy"`
+}
+
+type ProtectedBranch struct {
+	Name                 string   `json:"name" xml:"name"`
+	RequiredReviews      int      `json:"required_reviews" xml:"required_reviews"`
+	EnforceAdmins        bool     `json:"enforce_admins" xml:"enforce_admins"`
+	RequireSignedCommits bool     `json:"require_signed_commits" xml:"require_signed_commits"`
+	Restrictions         []string `json:"restrictions,omitempty" xml:"restriction,omitempty"`
+}
+
+type DeployKey struct {
+	ID        int    `json:"id" xml:"id"`
+	Title     string `json:"title" xml:"title"`
+	ReadOnly  bool   `json:"read_only" xml:"read_only"`
+	CreatedAt string `json:"created_at" xml:"created_at"`
+	LastUsed  string `json:"last_used,omitempty" xml:"last_used,omitempty"`
+}
+
+type KeyValue struct {
+	Key   string `json:"key" xml:"key"`
+	Value string `json:"value" xml:"value"`
+}
+
+type ActionsConfig struct {
+	SecretNames []string   `json:"secret_names,omitempty" xml:"secret_name,omitempty"`
+	Variables   []KeyValue `json:"variables,omitempty" xml:"variable,omitempty"`
+}
+
+type CodeOwners struct {
+	Exists    bool            `json:"exists" xml:"exists"`
+	Path      string          `json:"path,omitempty" xml:"path,omitempty"`
+	RuleCount int             `json:"rule_count" xml:"rule_count"`
+	Entries   []CodeOwnerRule `json:"entries,omitempty" xml:"entry,omitempty"`
+	Errors    []string        `json:"errors,omitempty" xml:"error,omitempty"`
+}
+
+type CodeOwnerRule struct {
+	Pattern string   `json:"pattern" xml:"pattern"`
+	Owners  []string `json:"owners" xml:"owner"`
+}
+
+type PagesConfig struct {
+	Enabled       bool   `json:"enabled" xml:"enabled"`
+	SourceBranch  string `json:"source_branch,omitempty" xml:"source_branch,omitempty"`
+	SourcePath    string `json:"source_path,omitempty" xml:"source_path,omitempty"`
+	CustomDomain  string `json:"custom_domain,omitempty" xml:"custom_domain,omitempty"`
+	HTTPSEnforced bool   `json:"https_enforced" xml:"https_enforced"`
+	Public        bool   `json:"public" xml:"public"`
+}
+
+type Autolink struct {
+	KeyPrefix      string `json:"key_prefix" xml:"key_prefix"`
+	URLTemplate    string `json:"url_template" xml:"url_template"`
+	IsAlphanumeric bool   `json:"is_alphanumeric" xml:"is_alphanumeric"`
+}
+
+// WorkflowsConfig lists the workflow files a repository defines. ActionsEnabled
+// is nil when the token cannot read the Actions permissions.
+type WorkflowsConfig struct {
+	ActionsEnabled *bool      `json:"actions_enabled,omitempty" xml:"actions_enabled,omitempty"`
+	Workflows      []Workflow `json:"workflows,omitempty" xml:"workflow,omitempty"`
+}
+
+type Workflow struct {
+	Name  string `json:"name" xml:"name"`
+	Path  string `json:"path" xml:"path"`
+	State string `json:"state" xml:"state"`
+}
+
+type Release struct {
+	TagName     string `json:"tag_name" xml:"tag_name"`
+	Name        string `json:"name,omitempty" xml:"name,omitempty"`
+	Draft       bool   `json:"draft" xml:"draft"`
+	Prerelease  bool   `json:"prerelease" xml:"prerelease"`
+	PublishedAt string `json:"published_at,omitempty" xml:"published_at,omitempty"`
+}
+
+type TagProtection struct {
+	Pattern string `json:"pattern" xml:"pattern"`
+}
+
+// Branch reports how far a branch has diverged from the default branch.
+// Stale is only set when --stale-days is given.
+type Branch struct {
+	Name           string `json:"name" xml:"name"`
+	Protected      bool   `json:"protected" xml:"protected"`
+	AheadBy        int    `json:"ahead_by" xml:"ahead_by"`
+	BehindBy       int    `json:"behind_by" xml:"behind_by"`
+	LastCommitDate string `json:"last_commit_date,omitempty" xml:"last_commit_date,omitempty"`
+	Stale          bool   `json:"stale,omitempty" xml:"stale,omitempty"`
+}
+
+// TagRule is a ruleset targeting tags. Enforcement is "active", "evaluate"
+// (report only) or "disabled".
+type TagRule struct {
+	Name        string `json:"name" xml:"name"`
+	Pattern     string `json:"pattern" xml:"pattern"`
+	Enforcement string `json:"enforcement" xml:"enforcement"`
+}
+
+type Templates struct {
+	IssueTemplates []string `json:"issue_templates,omitempty" xml:"issue_template,omitempty"`
+	HasPRTemplate  bool     `json:"has_pr_template" xml:"has_pr_template"`
+	HasConfigYML   bool     `json:"has_config_yml" xml:"has_config_yml"`
+}
+
+// CommunityProfile reports the community health files GitHub recognises.
+// License is the SPDX ID when GitHub could identify the license.
+type CommunityProfile struct {
+	HealthPercentage  int    `json:"health_percentage" xml:"health_percentage"`
+	HasLicense        bool   `json:"has_license" xml:"has_license"`
+	HasContributing   bool   `json:"has_contributing" xml:"has_contributing"`
+	HasCodeOfConduct  bool   `json:"has_code_of_conduct" xml:"has_code_of_conduct"`
+	HasSecurityPolicy bool   `json:"has_security_policy" xml:"has_security_policy"`
+	HasReadme         bool   `json:"has_readme" xml:"has_readme"`
+	License           string `json:"license,omitempty" xml:"license,omitempty"`
+}
+
+// Event is a governance-relevant entry of the repository's public event
+// feed, such as a collaborator being added or the repository going public
+type Event struct {
+	Type      string `json:"type" xml:"type"`
+	Actor     string `json:"actor" xml:"actor"`
+	CreatedAt string `json:"created_at" xml:"created_at"`
+	Detail    string `json:"detail,omitempty" xml:"detail,omitempty"`
+}
+
+type Dependabot struct {
+	Exists     bool     `json:"exists" xml:"exists"`
+	Path       string   `json:"path,omitempty" xml:"path,omitempty"`
+	Ecosystems []string `json:"ecosystems,omitempty" xml:"ecosystem,omitempty"`
+	Schedules  []string `json:"schedules,omitempty" xml:"schedule,omitempty"`
 }
 
 var (
-	outputFormat string
-	verbose      bool
-	sections     []string
+	outputFormat       string
+	verbosity          int
+	sections           []string
+	host               string
+	apiVersion         string
+	logFile            string
+	logFormat          string
+	policyFile         string
+	policyOnly         bool
+	execCommand        string
+	lang               string
+	showProgress       bool
+	yamlFlow           bool
+	yamlIndent         int
+	showEmpty          bool
+	dryRun             bool
+	strictPermissions  bool
+	outputFile         string
+	outputDir          string
+	templateText       string
+	orgName            string
+	repoLimit          int
+	includeForks       bool
+	excludeArchived    bool
+	concurrency        int
+	cacheDir           string
+	cacheTTL           time.Duration
+	inspectTimeout     time.Duration
+	maxRetries         int
+	releaseLimit       int
+	staleDays          int
+	jqExpr             string
+	since              string
+	sortBy             string
+	normalize          bool
+	maxWidth           int
+	manifestFile       string
+	reposFile          string
+	repoID             int64
+	showRateLimit      bool
+	quiet              bool
+	resolvePermissions bool
+	withActivity       bool
+	withAlerts         bool
+	withAudit          bool
+	eventLimit         int
+	inactiveDays       int
+	configFile         string
+	authToken          string
+	watch              bool
+	watchInterval      time.Duration
+	watchChangesOnly   bool
+	tableWidth         int
+	sinceTime          time.Time
+	changedSince       string
+	changedSinceTime   time.Time
+	noCache            bool
+	failOn             []string
+	fields             []string
+	fieldSelection     []fieldPath
+	redactCategories   []string
+	noColor            bool
+	compact            bool
+	useGraphQL         bool
 )
 
 func main() {
@@ -105,29 +454,293 @@ func main() {
 repository governance configuration without making changes.
 
 This tool inspects various aspects of repository governance including:
-- Repository rulesets and branch protection
+- Repository rulesets and classic branch protection
 - Required status checks
 - Collaborators and teams
 - Security settings
 - Repository configuration
-- Issue labels and milestones`,
+- Issue labels and milestones
+- Webhooks
+- Deployment environments
+- Deploy keys
+- GitHub Actions secret names and variables
+- CODEOWNERS presence and validity
+- GitHub Pages configuration
+- Autolink references
+- GitHub Actions workflows
+- Dependabot version update configuration
+- Recent releases and tag protection
+- Issue and pull request templates
+- Branches and their divergence from the default branch
+- Tag rulesets and their enforcement
+- Community health files
+- Recent governance events (with --with-audit)`,
 		Args: cobra.MaximumNArgs(1),
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			if err := applyConfigFile(cmd); err != nil {
+				return err
+			}
+			// An explicit --quiet wins over a verbose level from the config file
+			if quiet {
+				verbosity = 0
+				cmd.SilenceUsage = true
+				cmd.SilenceErrors = true
+			}
+			return configureLogging()
+		},
 		RunE: runInspect,
 	}
 
-	rootCmd.Flags().StringVarP(&outputFormat, "format", "f", "json", "Output format (json, yaml, table)")
-	rootCmd.Flags().BoolVarP(&verbose, "verbose", "v", false, "Enable verbose output")
-	rootCmd.Flags().StringSliceVarP(&sections, "sections", "s", []string{}, "Specific sections to inspect (rulesets, collaborators, teams, security, settings, labels, milestones)")
+	rootCmd.Flags().StringVarP(&outputFormat, "format", "f", "json", "Output format (json, yaml, toml, xml, table, csv, markdown, html, sarif, github-actions, ndjson, summary, summary-json)")
+	rootCmd.Flags().StringVar(&lang, "lang", defaultLang, "Language of the table output labels (en, de)")
+	rootCmd.Flags().BoolVar(&yamlFlow, "yaml-flow", false, "In YAML output, write lists of plain values in flow style, e.g. [ci, lint]")
+	rootCmd.Flags().IntVar(&yamlIndent, "yaml-indent", 4, "Indentation width of YAML output (2-9)")
+	rootCmd.Flags().BoolVar(&compact, "compact", false, "Render table output as flat \"Section > Field: value\" lines without tree characters or icons (implies --no-color)")
+	rootCmd.Flags().BoolVar(&noColor, "no-color", false, "Render icons as plain text (automatic for table output when stdout is not a terminal)")
+	rootCmd.Flags().StringVarP(&jqExpr, "jq", "q", "", "Filter JSON output using a jq expression")
+	rootCmd.Flags().StringVarP(&outputFile, "output", "o", "", "Write the report to a file instead of stdout")
+	rootCmd.Flags().StringVar(&templateText, "template", "", "Render each report with this Go text/template (or @file) instead of --format")
+	rootCmd.Flags().StringVar(&outputDir, "output-dir", "", "With --org or --repos-file, write each repository's report to <dir>/<owner>__<repo>.<ext> instead of one batch report")
+	rootCmd.Flags().StringVar(&execCommand, "exec", "", "Pipe the JSON report to this shell command's stdin and stream its output; its exit code becomes ours")
+	rootCmd.Flags().BoolVar(&strictPermissions, "strict-permissions", false, "Fail before inspecting when the token's OAuth scopes don't cover the requested sections, instead of warning")
+	rootCmd.Flags().BoolVar(&dryRun, "dry-run", false, "Print the API requests the selected sections would make for the repository without calling the API")
+	rootCmd.Flags().StringVar(&orgName, "org", "", "Inspect every repository in an organization instead of a single repository")
+	rootCmd.Flags().BoolVar(&watch, "watch", false, "Re-inspect the repository every --interval, emitting NDJSON records until interrupted")
+	rootCmd.Flags().DurationVar(&watchInterval, "interval", 5*time.Minute, "Time between inspections in --watch mode")
+	rootCmd.Flags().BoolVar(&watchChangesOnly, "watch-changes-only", false, "In --watch mode, only emit a record when something changed since the previous one")
+	rootCmd.Flags().BoolVar(&showEmpty, "show-empty", false, "In table output, list sections without data with a \"none configured\" line instead of omitting them")
+	rootCmd.Flags().IntVar(&maxWidth, "max-width", 0, "Truncate long values in table output to fit this width (default: terminal width)")
+	rootCmd.Flags().BoolVar(&normalize, "normalize", false, "Sort collaborators, teams, labels, milestones and required checks by name so reports diff cleanly")
+	rootCmd.Flags().StringVar(&sortBy, "sort", "", "Sort collaborators and teams by name, permission, or type (default: API order)")
+	rootCmd.Flags().StringVar(&since, "since", "", "Only include milestones updated since this RFC3339 date")
+	rootCmd.Flags().StringVar(&changedSince, "changed-since", "", "Only include rulesets updated after this date (RFC3339 or YYYY-MM-DD)")
+	rootCmd.Flags().StringSliceVar(&fields, "fields", []string{}, "Only render these dotted field paths in json and table output, e.g. settings.DefaultBranch,security.SecretScanning")
+	rootCmd.Flags().Int64Var(&repoID, "repo-id", 0, "Inspect the repository with this numeric ID, resolved to its current owner/repo")
+	rootCmd.Flags().StringVar(&reposFile, "repos-file", "", "Inspect the owner/repo entries listed one per line in this file as a batch")
+	rootCmd.Flags().StringVar(&manifestFile, "manifest", "", "With --org or --repos-file, write a JSON manifest of each repository's scan status, duration and error")
+	rootCmd.Flags().BoolVar(&showProgress, "progress", false, "With --org or --repos-file, show a scanning i/n counter on stderr (terminals only)")
+	rootCmd.Flags().IntVar(&repoLimit, "limit", 0, "Maximum number of repositories to inspect with --org (0 for no limit)")
+	rootCmd.Flags().BoolVar(&includeForks, "include-forks", false, "With --org, also inspect forked repositories")
+	rootCmd.Flags().BoolVar(&excludeArchived, "exclude-archived", true, "With --org, skip archived repositories")
+	rootCmd.PersistentFlags().IntVar(&concurrency, "concurrency", 4, "Maximum number of sections fetched in parallel")
+	rootCmd.PersistentFlags().StringVar(&cacheDir, "cache-dir", "", "Directory for caching API responses between runs (caching is off when empty)")
+	rootCmd.PersistentFlags().DurationVar(&cacheTTL, "cache-ttl", 10*time.Minute, "How long cached API responses stay fresh")
+	rootCmd.PersistentFlags().DurationVar(&inspectTimeout, "timeout", 30*time.Second, "Maximum time to inspect each repository before its remaining requests are cancelled (0 for no limit)")
+	rootCmd.PersistentFlags().BoolVar(&noCache, "no-cache", false, "Bypass the response cache entirely")
+	rootCmd.PersistentFlags().IntVar(&releaseLimit, "release-limit", 10, "Number of most recent releases to include")
+	rootCmd.PersistentFlags().IntVar(&staleDays, "stale-days", 0, "Flag branches whose last commit is older than this many days, and with --fail-on stale the repository's last push (0 to disable)")
+	rootCmd.PersistentFlags().IntVar(&maxRetries, "max-retries", 3, "Maximum retries for requests rejected by rate limits")
+	rootCmd.PersistentFlags().CountVarP(&verbosity, "verbose", "v", "Increase verbosity: -v section progress, -vv request URLs, -vvv response status and rate-limit headers")
+	// -q is taken by --jq, matching gh api
+	rootCmd.PersistentFlags().BoolVar(&quiet, "quiet", false, "Suppress all diagnostics on stderr except fatal errors")
+	rootCmd.MarkFlagsMutuallyExclusive("quiet", "verbose")
+	rootCmd.PersistentFlags().StringVar(&logFile, "log-file", "", "Also write diagnostics as structured entries (timestamp, level, repo, section, message) to this file")
+	rootCmd.PersistentFlags().StringVar(&logFormat, "log-format", "text", "Format of --log-file entries (text, json)")
+	rootCmd.PersistentFlags().BoolVar(&useGraphQL, "use-graphql", false, "Fetch rulesets through the GraphQL API, which includes bypass actors")
+	rootCmd.PersistentFlags().BoolVar(&showRateLimit, "show-rate-limit", false, "Print the remaining REST and GraphQL rate limit to stderr when the run finishes")
+	rootCmd.PersistentFlags().StringSliceVar(&redactCategories, "redact", []string{}, "Mask values in the output before sharing it (logins, urls, emails)")
+	rootCmd.PersistentFlags().BoolVar(&resolvePermissions, "resolve-permissions", false, "Look up each collaborator's role and whether access is direct, via a team or via the organization (one extra request per collaborator)")
+	rootCmd.PersistentFlags().BoolVar(&withActivity, "with-activity", false, "Look up each collaborator's most recent commit (one extra request per collaborator)")
+	rootCmd.PersistentFlags().BoolVar(&withAlerts, "with-alerts", false, "Count the open secret scanning and Dependabot alerts (requires security alert read access)")
+	rootCmd.PersistentFlags().BoolVar(&withAudit, "with-audit", false, "Include recent governance events (collaborators added, visibility changes) from the repository event feed")
+	rootCmd.PersistentFlags().IntVar(&eventLimit, "event-limit", 20, "With --with-audit, number of most recent governance events to include")
+	rootCmd.PersistentFlags().IntVar(&inactiveDays, "inactive-days", 0, "With --with-activity, flag collaborators without a commit in this many days (0 to disable)")
+	rootCmd.PersistentFlags().StringVar(&configFile, "config", "", "Config file of default flag values (defaults to "+configFileName+" in the current directory, then $HOME)")
+	rootCmd.PersistentFlags().StringVar(&authToken, "token", "", "GitHub token to authenticate with instead of GH_TOKEN or the gh credential")
+	rootCmd.PersistentFlags().StringVar(&apiVersion, "api-version", defaultAPIVersion, "REST API version sent as the "+apiVersionHeader+" header on every request (empty to omit the header)")
+	rootCmd.PersistentFlags().StringVar(&host, "host", "", "GitHub host to inspect against, e.g. a GitHub Enterprise Server hostname (defaults to GH_HOST or github.com)")
+	rootCmd.Flags().StringVar(&policyFile, "policy", "", "YAML governance baseline to check the repository against; exits non-zero on violations")
+	rootCmd.Flags().BoolVar(&policyOnly, "policy-only", false, "Emit only the --policy violations as a JSON array instead of the report (requires --format json)")
+	rootCmd.Flags().StringSliceVar(&failOn, "fail-on", []string{}, "Exit with code 2 when any condition matches ("+failConditionKeys()+")")
+	rootCmd.PersistentFlags().StringSliceVarP(&sections, "sections", "s", []string{}, "Specific sections to inspect (rulesets, collaborators, teams, security, settings, labels, milestones, webhooks, environments, branch-protection, deploy-keys, actions, codeowners, pages, autolinks, workflows, dependabot, releases, templates, branches, tag-rules, community-files, recent-events, summary)")
+
+	rootCmd.AddCommand(newDiffCmd())
+	rootCmd.AddCommand(newBrowseCmd())
+	rootCmd.AddCommand(newCompareTemplateCmd())
+	rootCmd.AddCommand(newSchemaCmd())
+	rootCmd.AddCommand(newDefaultBranchAuditCmd())
+	rootCmd.AddCommand(newDoctorCmd())
 
 	if err := rootCmd.Execute(); err != nil {
-		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		logger.Error(err.Error())
+		var exitErr *exitError
+		if errors.As(err, &exitErr) {
+			os.Exit(exitErr.code)
+		}
 		os.Exit(1)
 	}
 }
 
+// exitError carries a specific process exit code out of a command
+type exitError struct {
+	code int
+	err  error
+}
+
+func (e *exitError) Error() string {
+	return e.err.Error()
+}
+
+func (e *exitError) Unwrap() error {
+	return e.err
+}
+
 func runInspect(cmd *cobra.Command, args []string) error {
+	if err := validateFailConditions(failOn); err != nil {
+		return err
+	}
+
+	if jqExpr != "" && strings.ToLower(outputFormat) != "json" {
+		return fmt.Errorf("--jq requires --format json")
+	}
+
+	if compact && strings.ToLower(outputFormat) != "table" {
+		return fmt.Errorf("--compact requires --format table")
+	}
+
+	if yamlIndent < 2 || yamlIndent > 9 {
+		return fmt.Errorf("--yaml-indent must be between 2 and 9")
+	}
+
+	if inactiveDays > 0 && !withActivity {
+		return fmt.Errorf("--inactive-days requires --with-activity")
+	}
+
+	if err := validateExecHook(); err != nil {
+		return err
+	}
+
+	if orgName != "" || reposFile != "" {
+		switch {
+		case orgName != "" && reposFile != "":
+			return fmt.Errorf("--org cannot be combined with --repos-file")
+		case len(args) > 0:
+			return fmt.Errorf("--org and --repos-file cannot be combined with an owner/repo argument")
+		case watch:
+			return fmt.Errorf("--watch inspects a single repository and cannot be combined with --org or --repos-file")
+		case dryRun:
+			return fmt.Errorf("--dry-run lists the requests for a single repository and cannot be combined with --org or --repos-file")
+		}
+	}
+
+	if cmd.Flags().Changed("repo-id") {
+		switch {
+		case len(args) > 0:
+			return fmt.Errorf("--repo-id cannot be combined with an owner/repo argument")
+		case orgName != "" || reposFile != "":
+			return fmt.Errorf("--repo-id cannot be combined with --org or --repos-file")
+		case repoID <= 0:
+			return fmt.Errorf("--repo-id must be a positive repository ID")
+		case dryRun:
+			return fmt.Errorf("--dry-run makes no requests and cannot resolve --repo-id")
+		}
+	}
+
+	if templateText != "" {
+		switch {
+		case jqExpr != "":
+			return fmt.Errorf("--jq cannot be combined with --template")
+		case len(fields) > 0:
+			return fmt.Errorf("--fields cannot be combined with --template")
+		case execCommand != "":
+			return fmt.Errorf("--exec pipes the JSON report to the command and cannot be combined with --template")
+		case policyOnly:
+			return fmt.Errorf("--policy-only cannot be combined with --template")
+		case watch:
+			return fmt.Errorf("--watch emits NDJSON records and cannot be combined with --template")
+		}
+		if err := parseReportTemplate(templateText); err != nil {
+			return err
+		}
+	}
+
+	if outputDir != "" {
+		switch {
+		case orgName == "" && reposFile == "":
+			return fmt.Errorf("--output-dir requires --org or --repos-file")
+		case outputFile != "":
+			return fmt.Errorf("--output-dir cannot be combined with --output")
+		case policyOnly:
+			return fmt.Errorf("--output-dir cannot be combined with --policy-only, which writes a single violations report")
+		}
+	}
+
+	if policyOnly {
+		switch {
+		case policyFile == "":
+			return fmt.Errorf("--policy-only requires --policy")
+		case strings.ToLower(outputFormat) != "json":
+			return fmt.Errorf("--policy-only requires --format json")
+		}
+	}
+
+	if len(fields) > 0 {
+		if format := strings.ToLower(outputFormat); format != "json" && format != "table" {
+			return fmt.Errorf("--fields requires --format json or table")
+		}
+		selection, err := parseFieldPaths(fields)
+		if err != nil {
+			return err
+		}
+		fieldSelection = selection
+	}
+
+	if err := validateRedactCategories(redactCategories); err != nil {
+		return err
+	}
+
+	if sortBy != "" && !slices.Contains(sortKeys, sortBy) {
+		return fmt.Errorf("unknown --sort key %q (available: %s)", sortBy, strings.Join(sortKeys, ", "))
+	}
+
+	if since != "" {
+		parsed, err := time.Parse(time.RFC3339, since)
+		if err != nil {
+			return fmt.Errorf("invalid --since date %q: expected RFC3339, e.g. 2024-01-31T00:00:00Z", since)
+		}
+		sinceTime = parsed
+	}
+
+	if changedSince != "" {
+		parsed, err := time.Parse(time.RFC3339, changedSince)
+		if err != nil {
+			parsed, err = time.Parse(time.DateOnly, changedSince)
+		}
+		if err != nil {
+			return fmt.Errorf("invalid --changed-since date %q: expected RFC3339 or YYYY-MM-DD, e.g. 2024-01-31", changedSince)
+		}
+		changedSinceTime = parsed
+	}
+
+	configurePlainOutput()
+	configureTableWidth()
+	configureLanguage()
+
+	// A dry run makes no requests, not even for the rate limit
+	if showRateLimit && !dryRun {
+		defer printRateLimit("")
+	}
+
+	if orgName != "" || reposFile != "" {
+		if err := checkTokenPermissions(); err != nil {
+			return err
+		}
+		if reposFile != "" {
+			noteOrgOnlyFlags(cmd)
+			return runReposFileInspect(cmd, reposFile)
+		}
+		return runOrgInspect(cmd, orgName)
+	}
+	noteOrgOnlyFlags(cmd)
+
 	var repo string
-	if len(args) == 0 {
+	if cmd.Flags().Changed("repo-id") {
+		resolved, err := resolveRepoID(repoID)
+		if err != nil {
+			return err
+		}
+		repo = resolved
+	} else if len(args) == 0 {
 		// Try to get repo from current directory
 		currentRepo, err := getCurrentRepo()
 		if err != nil {
@@ -135,52 +748,346 @@ func runInspect(cmd *cobra.Command, args []string) error {
 		}
 		repo = currentRepo
 	} else {
-		repo = args[0]
+		// Expand references such as $GITHUB_REPOSITORY that reach us unexpanded,
+		// e.g. from workflow files that don't run through a shell
+		repo = os.ExpandEnv(args[0])
 	}
 
-	parts := strings.Split(repo, "/")
-	if len(parts) != 2 {
-		return fmt.Errorf("repository must be in format 'owner/repo'")
+	repoHost, owner, repoName, err := utils.ParseRepoArg(repo)
+	if err != nil {
+		return err
+	}
+	if host == "" && repoHost != "" {
+		host = repoHost
 	}
 
-	owner, repoName := parts[0], parts[1]
+	if dryRun {
+		return runDryRun(owner, repoName)
+	}
+
+	// Checked once the host of an owner/repo argument is known
+	if err := checkTokenPermissions(); err != nil {
+		return err
+	}
 
-	if verbose {
-		fmt.Fprintf(os.Stderr, "Inspecting repository: %s/%s\n", owner, repoName)
+	if watch {
+		return runWatch(owner, repoName)
 	}
 
+	// Load the policy up front so a bad file fails before any API calls
+	policy, err := loadPolicyFlag()
+	if err != nil {
+		return err
+	}
+
+	logger.Info(fmt.Sprintf("Inspecting repository: %s/%s", owner, repoName), repoAttr(owner, repoName))
+
 	governance, err := inspectRepository(owner, repoName)
 	if err != nil {
 		return fmt.Errorf("failed to inspect repository: %v", err)
 	}
 
-	return outputGovernance(governance, sections)
+	var violations []Violation
+	if policy != nil {
+		violations = checkPolicy(governance, policy)
+		if reportsViolations(outputFormat) {
+			governance.Violations = violations
+		}
+	}
+
+	err = writeReport(func(w io.Writer) error {
+		if policyOnly {
+			return outputViolations(w, redactViolations(violations, redactCategories))
+		}
+		return outputGovernance(w, governance, sections)
+	})
+	if err != nil {
+		return err
+	}
+
+	return reportGates(cmd, violations, checkFailConditions(governance, failOn))
 }
 
-func getCurrentRepo() (string, error) {
-	client, err := api.DefaultRESTClient()
+// noteOrgOnlyFlags mentions in verbose mode that the org repository filters
+// have no effect on an explicit list of repositories
+func noteOrgOnlyFlags(cmd *cobra.Command) {
+	for _, name := range []string{"include-forks", "exclude-archived"} {
+		if cmd.Flags().Changed(name) {
+			logger.Info(fmt.Sprintf("--%s only applies to --org and is ignored", name))
+		}
+	}
+}
+
+// configurePlainOutput switches the icon helpers to plain text when asked to,
+// or when table output is not going to a terminal. Markdown keeps its icons
+// unless --no-color is set since it is usually rendered elsewhere.
+func configurePlainOutput() {
+	if noColor || compact || os.Getenv("NO_COLOR") != "" {
+		utils.PlainOutput = true
+		return
+	}
+	if strings.ToLower(outputFormat) == "table" {
+		utils.PlainOutput = outputFile != "" || outputDir != "" || !term.FromEnv().IsTerminalOutput()
+	}
+}
+
+// configureTableWidth picks the width table output is truncated to: --max-width
+// when set, otherwise the terminal width. Output that isn't going to a
+// terminal is never truncated unless asked for.
+func configureTableWidth() {
+	if maxWidth > 0 {
+		tableWidth = maxWidth
+		return
+	}
+	terminal := term.FromEnv()
+	if outputFile != "" || outputDir != "" || !terminal.IsTerminalOutput() {
+		return
+	}
+	if width, _, err := terminal.Size(); err == nil {
+		tableWidth = width
+	}
+}
+
+// loadPolicyFlag loads the --policy baseline, returning nil when the flag is unset
+func loadPolicyFlag() (*governancePolicy, error) {
+	if policyFile == "" {
+		return nil, nil
+	}
+	return loadPolicy(policyFile)
+}
+
+// reportGates prints policy violations and tripped --fail-on conditions to
+// stderr and turns them into a command error. Tripped conditions exit with
+// code 2, policy violations with code 1.
+func reportGates(cmd *cobra.Command, violations []Violation, tripped []string) error {
+	// With --quiet only the summary error below is printed
+	for _, violation := range redactViolations(violations, redactCategories) {
+		if violation.Repository != "" {
+			logNotice(fmt.Sprintf("Policy violation: %s: %s", violation.Repository, violation), slog.String("repo", violation.Repository))
+		} else {
+			logNotice(fmt.Sprintf("Policy violation: %s", violation))
+		}
+	}
+	for _, condition := range tripped {
+		logNotice(fmt.Sprintf("Fail condition tripped: %s", condition))
+	}
+
+	if len(tripped) > 0 {
+		cmd.SilenceUsage = true
+		return &exitError{code: 2, err: fmt.Errorf("%d fail condition(s) tripped", len(tripped))}
+	}
+	if len(violations) > 0 {
+		cmd.SilenceUsage = true
+		return fmt.Errorf("%d policy violation(s) found", len(violations))
+	}
+	return nil
+}
+
+// writeReport renders the report to --output when set, through the --exec
+// command when set, otherwise to stdout. The file is only created once
+// inspection succeeded so a failed run doesn't truncate a previous report.
+func writeReport(render func(w io.Writer) error) error {
+	if execCommand != "" {
+		return runExecHook(render)
+	}
+	if outputFile == "" {
+		return render(os.Stdout)
+	}
+
+	file, err := os.Create(outputFile)
+	if err != nil {
+		return fmt.Errorf("failed to create output file: %v", err)
+	}
+
+	if err := render(file); err != nil {
+		file.Close()
+		return fmt.Errorf("failed to write output file: %v", err)
+	}
+
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("failed to write output file: %v", err)
+	}
+
+	logger.Info(fmt.Sprintf("Report written to %s", outputFile))
+
+	return nil
+}
+
+// resolveHost returns the --host flag, falling back to GH_HOST. An empty
+// result means the default host configured for the GitHub CLI.
+func resolveHost() string {
+	if host != "" {
+		return host
+	}
+	return os.Getenv("GH_HOST")
+}
+
+const restAcceptHeader = "application/vnd.github+json"
+
+// defaultAPIVersion is the REST API version the requests are written
+// against. It is supported by github.com and GHES 3.9 and later.
+const defaultAPIVersion = "2022-11-28"
+
+// apiVersionHeader carries --api-version on every request
+const apiVersionHeader = "X-GitHub-Api-Version"
+
+// clientOptions holds the settings shared by the REST and GraphQL clients.
+// Without --token, go-gh reads GH_TOKEN/GITHUB_TOKEN (GH_ENTERPRISE_TOKEN for
+// GHES) and falls back to the credential stored by gh auth login.
+func clientOptions() api.ClientOptions {
+	headers := map[string]string{}
+	// An empty --api-version leaves the choice to the server, for older
+	// servers that don't know the header
+	if apiVersion != "" {
+		headers[apiVersionHeader] = apiVersion
+	}
+	return api.ClientOptions{
+		Host:      resolveHost(),
+		AuthToken: authToken,
+		Headers:   headers,
+		Transport: newLoggingTransport(),
+	}
+}
+
+// authError replaces go-gh's missing-token error with one that lists the ways
+// to authenticate. The token itself never appears in errors.
+func authError(err error) error {
+	if strings.Contains(err.Error(), "authentication token not found") {
+		return fmt.Errorf("no GitHub credentials found: pass --token, set GH_TOKEN, or run gh auth login")
+	}
+	return err
+}
+
+// newRESTClient creates a REST client for the resolved host that sends its
+// requests with ctx. Rate-limited requests are retried up to --max-retries
+// times, and the client is wrapped in the response cache when --cache-dir is
+// set.
+func newRESTClient(ctx context.Context) (apiClient, error) {
+	targetHost := resolveHost()
+
+	// go-gh defaults to preview media types; endpoints such as autolinks
+	// only answer to the stable one
+	opts := clientOptions()
+	opts.Headers["Accept"] = restAcceptHeader
+	restClient, err := api.NewRESTClient(opts)
+	if err != nil {
+		return nil, authError(err)
+	}
+
+	var client apiClient = &contextClient{client: restClient, ctx: ctx}
+	if maxRetries > 0 {
+		client = newRetryingClient(client, maxRetries)
+	}
+
+	if cacheDir == "" || noCache {
+		return client, nil
+	}
+	return newCachingClient(client, filepath.Join(cacheDir, cacheHostDir(targetHost)), cacheTTL)
+}
+
+// resolveRepoID looks up the current owner/repo of a repository ID, which
+// stays the same across renames and transfers
+func resolveRepoID(id int64) (string, error) {
+	ctx, cancel := inspectContext()
+	defer cancel()
+	client, err := newRESTClient(ctx)
 	if err != nil {
 		return "", err
 	}
 
-	// This is a simplified approach - in a real implementation,
-	// you might want to parse .git/config or use git commands
-	response := struct {
+	var repository struct {
 		FullName string `json:"full_name"`
-	}{}
+	}
+	if err := client.Get(fmt.Sprintf("repositories/%d", id), &repository); err != nil {
+		if isNotFound(err) {
+			return "", fmt.Errorf("no repository with ID %d, or the token can't read it", id)
+		}
+		return "", fmt.Errorf("failed to resolve repository ID %d: %v", id, err)
+	}
+
+	logger.Info(fmt.Sprintf("Resolved repository ID %d to %s", id, repository.FullName))
+	return repository.FullName, nil
+}
+
+// getCurrentRepo determines the repository of the working directory from its
+// git remotes, returned as "host/owner/repo"
+func getCurrentRepo() (string, error) {
+	current, err := repository.Current()
+	if err == nil {
+		return fmt.Sprintf("%s/%s/%s", current.Host, current.Owner, current.Name), nil
+	}
+	logger.Warn(fmt.Sprintf("failed to resolve repository from git remotes: %v", err))
 
-	err = client.Get("user/repos", &response)
+	// Fall back to the origin remote, which also covers hosts gh is not logged in to
+	if err := exec.Command("git", "rev-parse", "--is-inside-work-tree").Run(); err != nil {
+		return "", fmt.Errorf("current directory is not a git repository")
+	}
+
+	output, err := exec.Command("git", "config", "--get", "remote.origin.url").Output()
+	if err != nil {
+		return "", fmt.Errorf("git repository has no origin remote")
+	}
+
+	remoteHost, owner, repo, err := utils.ParseGitRemote(strings.TrimSpace(string(output)))
 	if err != nil {
 		return "", err
 	}
+	return fmt.Sprintf("%s/%s/%s", remoteHost, owner, repo), nil
+}
 
-	return response.FullName, nil
+// sectionFetcher describes one API-backed section of the report. Fetchers
+// with an empty section name always run.
+type sectionFetcher struct {
+	section string
+	label   string
+	fetch   func(client apiClient, owner, repo string, governance *GovernanceConfig) error
+}
+
+// emptyRepoSkippedSections need a branch to read from and are skipped for
+// repositories without commits, where every request would 404
+var emptyRepoSkippedSections = []string{"codeowners", "branches", "branch-protection"}
+
+var sectionFetchers = []sectionFetcher{
+	{section: "", label: "repository settings", fetch: getRepositorySettings},
+	{section: "rulesets", label: "rulesets", fetch: fetchRulesets},
+	{section: "collaborators", label: "collaborators", fetch: getCollaborators},
+	{section: "teams", label: "teams", fetch: getTeams},
+	{section: "security", label: "security settings", fetch: getSecuritySettings},
+	{section: "labels", label: "labels", fetch: getLabels},
+	{section: "milestones", label: "milestones", fetch: getMilestones},
+	{section: "webhooks", label: "webhooks", fetch: getWebhooks},
+	{section: "environments", label: "environments", fetch: getEnvironments},
+	{section: "branch-protection", label: "branch protection", fetch: getProtectedBranches},
+	{section: "deploy-keys", label: "deploy keys", fetch: getDeployKeys},
+	{section: "actions", label: "actions configuration", fetch: getActionsConfig},
+	{section: "codeowners", label: "CODEOWNERS", fetch: getCodeOwners},
+	{section: "pages", label: "Pages configuration", fetch: getPages},
+	{section: "autolinks", label: "autolinks", fetch: getAutolinks},
+	{section: "workflows", label: "workflows", fetch: getWorkflows},
+	{section: "dependabot", label: "Dependabot configuration", fetch: getDependabot},
+	{section: "releases", label: "releases", fetch: getReleases},
+	{section: "templates", label: "issue and PR templates", fetch: getTemplates},
+	{section: "branches", label: "branches", fetch: getBranches},
+	{section: "tag-rules", label: "tag rulesets", fetch: getTagRules},
+	{section: "community-files", label: "community files", fetch: getCommunityFiles},
+	{section: "recent-events", label: "recent events", fetch: getRecentEvents},
 }
 
 func inspectRepository(owner, repo string) (*GovernanceConfig, error) {
-	client, err := api.DefaultRESTClient()
+	governance, _, err := inspectRepositorySections(owner, repo)
+	return governance, err
+}
+
+// inspectRepositorySections is inspectRepository that also returns the
+// sections that failed, as "label: error" strings. A failed section leaves its
+// part of the report empty rather than failing the whole inspection.
+func inspectRepositorySections(owner, repo string) (*GovernanceConfig, []string, error) {
+	// The timeout is per repository, so a batch isn't cut short by its size
+	ctx, cancel := inspectContext()
+	defer cancel()
+	client, err := newRESTClient(ctx)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	governance := &GovernanceConfig{
@@ -190,121 +1097,175 @@ func inspectRepository(owner, repo string) (*GovernanceConfig, error) {
 		},
 	}
 
-	// Get repository basic information
-	if err := getRepositorySettings(*client, owner, repo, governance); err != nil {
-		if verbose {
-			fmt.Fprintf(os.Stderr, "Warning: failed to get repository settings: %v\n", err)
-		}
+	limit := concurrency
+	if limit < 1 {
+		limit = 1
 	}
+	sem := make(chan struct{}, limit)
 
-	// Get rulesets if requested or if no specific sections
-	if shouldIncludeSection("rulesets") {
-		if err := getRulesets(*client, owner, repo, governance); err != nil {
-			if verbose {
-				fmt.Fprintf(os.Stderr, "Warning: failed to get rulesets: %v\n", err)
-			}
-		}
-	}
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	var sectionErrors []string
+	run := func(fetcher sectionFetcher) {
+		sem <- struct{}{}
+		defer func() { <-sem }()
 
-	// Get collaborators if requested or if no specific sections
-	if shouldIncludeSection("collaborators") {
-		if err := getCollaborators(*client, owner, repo, governance); err != nil {
-			if verbose {
-				fmt.Fprintf(os.Stderr, "Warning: failed to get collaborators: %v\n", err)
-			}
+		// Each fetcher fills its own partial config so the shared result
+		// is only touched under the mutex
+		partial := &GovernanceConfig{}
+		section := fetcher.section
+		if section == "" {
+			// Always fetched, and rendered as the settings section
+			section = "settings"
+		}
+		sectionLogger := logger.With(repoAttr(owner, repo), slog.String("section", section))
+		sectionLogger.Info(fmt.Sprintf("Fetching %s", fetcher.label))
+		// Timed after the semaphore so waiting for a slot isn't counted
+		start := time.Now()
+		fetchErr := fetcher.fetch(client, owner, repo, partial)
+		elapsed := time.Since(start).Round(time.Millisecond)
+		if fetchErr != nil && errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			// Fetchers wrap their errors as text, so the expired context
+			// tells a timeout apart from other failures
+			fetchErr = fmt.Errorf("timed out after --timeout %v", inspectTimeout)
+			sectionLogger.Warn(fmt.Sprintf("section %s timed out after %v", section, inspectTimeout))
+		} else if isAppScopeForbidden(fetchErr) {
+			// Installation tokens only reach what the App was granted;
+			// the section is left out rather than reported as failed
+			sectionLogger.Info(fmt.Sprintf("skipping %s, the GitHub App installation is not granted the permission it needs (%v)", fetcher.label, fetchErr))
+			fetchErr = nil
+		} else if fetchErr != nil {
+			sectionLogger.Warn(fmt.Sprintf("failed to get %s after %v: %v", fetcher.label, elapsed, fetchErr), slog.Int64("duration_ms", elapsed.Milliseconds()))
+		} else {
+			sectionLogger.Info(fmt.Sprintf("%s fetched in %v", fetcher.label, elapsed), slog.Int64("duration_ms", elapsed.Milliseconds()))
 		}
-	}
 
-	// Get teams if requested or if no specific sections
-	if shouldIncludeSection("teams") {
-		if err := getTeams(*client, owner, repo, governance); err != nil {
-			if verbose {
-				fmt.Fprintf(os.Stderr, "Warning: failed to get teams: %v\n", err)
-			}
+		mu.Lock()
+		if fetchErr != nil {
+			sectionErrors = append(sectionErrors, fmt.Sprintf("%s: %v", fetcher.label, fetchErr))
 		}
+		mergeGovernance(governance, partial)
+		mu.Unlock()
 	}
 
-	// Get security settings if requested or if no specific sections
-	if shouldIncludeSection("security") {
-		if err := getSecuritySettings(*client, owner, repo, governance); err != nil {
-			if verbose {
-				fmt.Fprintf(os.Stderr, "Warning: failed to get security settings: %v\n", err)
+	started := time.Now()
+	// The repository settings come first since an empty repository skips
+	// the sections that need a branch
+	run(sectionFetchers[0])
+	empty := governance.RepoSettings.Empty
+	if empty {
+		var skipped []string
+		for _, section := range emptyRepoSkippedSections {
+			if shouldIncludeSection(section) {
+				skipped = append(skipped, section)
 			}
 		}
+		if len(skipped) > 0 {
+			logger.Info(fmt.Sprintf("%s/%s has no commits yet, skipping %s", owner, repo, strings.Join(skipped, ", ")), repoAttr(owner, repo))
+		}
 	}
 
-	// Get labels if requested or if no specific sections
-	if shouldIncludeSection("labels") {
-		if err := getLabels(*client, owner, repo, governance); err != nil {
-			if verbose {
-				fmt.Fprintf(os.Stderr, "Warning: failed to get labels: %v\n", err)
-			}
+	for _, fetcher := range sectionFetchers[1:] {
+		// Get each section if requested or if no specific sections
+		if !shouldIncludeSection(fetcher.section) {
+			continue
 		}
+		if empty && slices.Contains(emptyRepoSkippedSections, fetcher.section) {
+			continue
+		}
+
+		wg.Add(1)
+		go func(fetcher sectionFetcher) {
+			defer wg.Done()
+			run(fetcher)
+		}(fetcher)
 	}
+	wg.Wait()
 
-	// Get milestones if requested or if no specific sections
-	if shouldIncludeSection("milestones") {
-		if err := getMilestones(*client, owner, repo, governance); err != nil {
-			if verbose {
-				fmt.Fprintf(os.Stderr, "Warning: failed to get milestones: %v\n", err)
-			}
-		}
+	// With concurrency the sections overlap, so the total is wall time
+	// rather than the sum of the per-section times
+	logger.Info(fmt.Sprintf("%s/%s inspected in %v", owner, repo, time.Since(started).Round(time.Millisecond)), repoAttr(owner, repo))
+
+	governance.Summary = computeSummary(governance)
+	if normalize {
+		normalizeGovernance(governance)
 	}
-		Collaborators: []Collaborator{
-			{
-				Login:      "maintainer1",
-				Permission: "admin",
-				Type:       "User",
-			},
-			{
-				Login:      "developer1",
-				Permission: "write",
-				Type:       "User",
-			},
-		},
-		Teams: []Team{
-			{
-				Name:       "Core Team",
-				Slug:       "core-team",
-				Permission: "admin",
-			},
-			{
-				Name:       "Contributors",
-				Slug:       "contributors",
-				Permission: "write",
-			},
-			{
-				Name:       "Reviewers",
-				Slug:       "reviewers",
-				Permission: "triage",
-			},
-		},
-		SecuritySettings: SecuritySettings{
-			VulnerabilityAlerts:          true,
-			AutomatedSecurityFixes:       true,
-			SecretScanning:               true,
-			SecretScanningPushProtection: true,
-			DependencyGraphEnabled:       true,
-		},
-		RepoSettings: RepositorySettings{
-			Private:             false,
-			Archived:            false,
-			DefaultBranch:       "main",
-			AllowMergeCommit:    true,
-			AllowSquashMerge:    true,
-			AllowRebaseMerge:    true,
-			DeleteBranchOnMerge: true,
-			HasIssues:           true,
-			HasProjects:         false,
-			HasWiki:             false,
-		},
+	// Stable, so with --normalize ties keep the normalized order
+	sortAccessLists(governance, sortBy)
+
+	// Goroutines finish in any order; keep the report stable
+	sort.Strings(sectionErrors)
+
+	return governance, sectionErrors, nil
+}
+
+// normalizeGovernance puts the lists whose API order isn't stable between
+// runs into a fixed order, so exported reports only differ where the
+// configuration does
+func normalizeGovernance(governance *GovernanceConfig) {
+	sort.SliceStable(governance.Collaborators, func(i, j int) bool {
+		return strings.ToLower(governance.Collaborators[i].Login) < strings.ToLower(governance.Collaborators[j].Login)
+	})
+	sort.SliceStable(governance.Teams, func(i, j int) bool {
+		return governance.Teams[i].Slug < governance.Teams[j].Slug
+	})
+	sort.SliceStable(governance.IssueLabels, func(i, j int) bool {
+		return strings.ToLower(governance.IssueLabels[i].Name) < strings.ToLower(governance.IssueLabels[j].Name)
+	})
+	sort.SliceStable(governance.Milestones, func(i, j int) bool {
+		return governance.Milestones[i].Title < governance.Milestones[j].Title
+	})
+	for i := range governance.Rulesets {
+		sort.Strings(governance.Rulesets[i].RequiredStatusChecks)
 	}
+	sort.Strings(governance.RequiredChecks)
+}
 
-	if verbose {
-		fmt.Fprintf(os.Stderr, "Note: Using mock data for demonstration. In production, this would fetch real data from GitHub API.\n")
+// sortKeys are the accepted --sort values
+var sortKeys = []string{"name", "permission", "type"}
+
+// sortAccessLists orders collaborators and teams by key, keeping the API order
+// for ties and when key is empty. Permission sorts put the most privileged
+// first; teams have no type, so "type" leaves them as returned.
+func sortAccessLists(governance *GovernanceConfig, key string) {
+	switch key {
+	case "name":
+		sort.SliceStable(governance.Collaborators, func(i, j int) bool {
+			return strings.ToLower(governance.Collaborators[i].Login) < strings.ToLower(governance.Collaborators[j].Login)
+		})
+		sort.SliceStable(governance.Teams, func(i, j int) bool {
+			return strings.ToLower(governance.Teams[i].Name) < strings.ToLower(governance.Teams[j].Name)
+		})
+	case "permission":
+		sort.SliceStable(governance.Collaborators, func(i, j int) bool {
+			return utils.PermissionRank(governance.Collaborators[i].Permission) > utils.PermissionRank(governance.Collaborators[j].Permission)
+		})
+		sort.SliceStable(governance.Teams, func(i, j int) bool {
+			return utils.PermissionRank(governance.Teams[i].Permission) > utils.PermissionRank(governance.Teams[j].Permission)
+		})
+	case "type":
+		sort.SliceStable(governance.Collaborators, func(i, j int) bool {
+			return governance.Collaborators[i].Type < governance.Collaborators[j].Type
+		})
 	}
+}
 
-	return governance, nil
+// mergeGovernance copies every populated field of src into dst, appending
+// slices so several fetchers may contribute to the same list
+func mergeGovernance(dst, src *GovernanceConfig) {
+	dstValue := reflect.ValueOf(dst).Elem()
+	srcValue := reflect.ValueOf(src).Elem()
+	for i := 0; i < srcValue.NumField(); i++ {
+		field := srcValue.Field(i)
+		if field.IsZero() {
+			continue
+		}
+		if field.Kind() == reflect.Slice {
+			dstValue.Field(i).Set(reflect.AppendSlice(dstValue.Field(i), field))
+		} else {
+			dstValue.Field(i).Set(field)
+		}
+	}
 }
 
 func shouldIncludeSection(section string) bool {
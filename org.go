@@ -0,0 +1,356 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/cli/go-gh/v2/pkg/term"
+	"github.com/jefeish/gh-repo-inspect/utils"
+	"github.com/spf13/cobra"
+)
+
+// scanManifest records the outcome of every repository in a batch scan. It is
+// rewritten after each repository so an interrupted scan still leaves a
+// complete record of what was done.
+type scanManifest struct {
+	ScannedAt string          `json:"scanned_at"`
+	Org       string          `json:"org,omitempty"`
+	Repos     []manifestEntry `json:"repos"`
+}
+
+// manifestEntry has status "ok", "partial" when some sections failed (for
+// example on rate limits or missing permissions), or "failed"
+type manifestEntry struct {
+	Repo          string   `json:"repo"`
+	Status        string   `json:"status"`
+	DurationMs    int64    `json:"duration_ms"`
+	Error         string   `json:"error,omitempty"`
+	SectionErrors []string `json:"section_errors,omitempty"`
+}
+
+func (m *scanManifest) record(repo string, duration time.Duration, sectionErrors []string, err error) {
+	entry := manifestEntry{Repo: repo, Status: "ok", DurationMs: duration.Milliseconds(), SectionErrors: sectionErrors}
+	switch {
+	case err != nil:
+		entry.Status = "failed"
+		entry.Error = err.Error()
+	case len(sectionErrors) > 0:
+		entry.Status = "partial"
+	}
+	m.Repos = append(m.Repos, entry)
+}
+
+func (m *scanManifest) write(path string) error {
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("failed to write manifest: %v", err)
+	}
+	if err := writeCacheFile(path, data); err != nil {
+		return fmt.Errorf("failed to write manifest: %v", err)
+	}
+	return nil
+}
+
+// scanProgress rewrites a single "scanning i/n: owner/repo" line on stderr
+// during batch scans and ends with the scanned and failed totals
+type scanProgress struct {
+	enabled bool
+	total   int
+	failed  int
+}
+
+// newScanProgress enables the progress line for --progress unless it would
+// mix with other stderr output or isn't going to a terminal. Verbose output
+// already reports each repository.
+func newScanProgress(total int) *scanProgress {
+	enabled := showProgress && !quiet && verbosity < verboseProgress && term.IsTerminal(os.Stderr)
+	return &scanProgress{enabled: enabled, total: total}
+}
+
+func (p *scanProgress) update(index int, repo string) {
+	if p.enabled {
+		fmt.Fprintf(os.Stderr, "\r\033[Kscanning %d/%d: %s", index+1, p.total, repo)
+	}
+}
+
+func (p *scanProgress) done() {
+	if p.enabled {
+		fmt.Fprintf(os.Stderr, "\r\033[KScanned %d repositories, %d failed\n", p.total, p.failed)
+	}
+}
+
+// runOrgInspect inspects every repository in an organization as one batch
+func runOrgInspect(cmd *cobra.Command, org string) error {
+	client, err := newRESTClient(context.Background())
+	if err != nil {
+		return err
+	}
+
+	listed, err := listOrgRepos(client, org, repoLimit)
+	if err != nil {
+		return fmt.Errorf("failed to list repositories for %s: %v", org, err)
+	}
+
+	logger.Info(fmt.Sprintf("Inspecting %d repositories in %s", len(listed), org))
+
+	repos := make([]RepoInfo, 0, len(listed))
+	for _, repo := range listed {
+		repos = append(repos, RepoInfo{Owner: org, Name: repo.Name})
+	}
+	return runBatchInspect(cmd, org, repos)
+}
+
+// runReposFileInspect inspects the repositories listed in a file as one batch
+func runReposFileInspect(cmd *cobra.Command, path string) error {
+	repos, err := readReposFile(path)
+	if err != nil {
+		return err
+	}
+
+	logger.Info(fmt.Sprintf("Inspecting %d repositories from %s", len(repos), path))
+
+	return runBatchInspect(cmd, "", repos)
+}
+
+// readReposFile reads a file of owner/repo entries, one per line. Blank lines
+// and # comments are ignored; malformed lines are reported and skipped.
+func readReposFile(path string) ([]RepoInfo, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read repos file: %v", err)
+	}
+
+	var repos []RepoInfo
+	for i, line := range strings.Split(string(data), "\n") {
+		if idx := strings.Index(line, "#"); idx >= 0 {
+			line = line[:idx]
+		}
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+
+		repoHost, owner, name, err := utils.ParseRepoArg(line)
+		if err == nil && repoHost != "" {
+			err = fmt.Errorf("hosts are not supported in a repos file, use --host")
+		}
+		if err != nil {
+			logger.Warn(fmt.Sprintf("%s:%d: skipping %q: %v", path, i+1, line, err))
+			continue
+		}
+		repos = append(repos, RepoInfo{Owner: owner, Name: name})
+	}
+	return repos, nil
+}
+
+// runBatchInspect inspects repos and renders the results as one batch, or
+// streams them per repository for ndjson and to one file per repository
+// with --output-dir. org only labels the manifest.
+func runBatchInspect(cmd *cobra.Command, org string, repos []RepoInfo) error {
+	policy, err := loadPolicyFlag()
+	if err != nil {
+		return err
+	}
+
+	manifest := &scanManifest{ScannedAt: time.Now().UTC().Format(time.RFC3339), Org: org, Repos: []manifestEntry{}}
+	if manifestFile != "" {
+		if err := manifest.write(manifestFile); err != nil {
+			return err
+		}
+	}
+
+	var violations []Violation
+	var tripped []string
+	progress := newScanProgress(len(repos))
+	inspectAll := func(emit func(governance *GovernanceConfig) error) error {
+		defer progress.done()
+		for i, repo := range repos {
+			fullName := repo.Owner + "/" + repo.Name
+			logger.Info(fmt.Sprintf("Inspecting repository: %s", fullName), slog.String("repo", fullName))
+			progress.update(i, fullName)
+
+			started := time.Now()
+			governance, sectionErrors, err := inspectRepositorySections(repo.Owner, repo.Name)
+			manifest.record(fullName, time.Since(started), sectionErrors, err)
+			if manifestFile != "" {
+				if err := manifest.write(manifestFile); err != nil {
+					return err
+				}
+			}
+			if err != nil {
+				progress.failed++
+				logger.Warn(fmt.Sprintf("failed to inspect %s: %v", fullName, err), slog.String("repo", fullName))
+				continue
+			}
+
+			if policy != nil {
+				repoViolations := checkPolicy(governance, policy)
+				if reportsViolations(outputFormat) {
+					governance.Violations = repoViolations
+				}
+				for _, violation := range repoViolations {
+					violation.Repository = fullName
+					violations = append(violations, violation)
+				}
+			}
+			for _, condition := range checkFailConditions(governance, failOn) {
+				tripped = append(tripped, fmt.Sprintf("%s: %s", fullName, condition))
+			}
+
+			if err := emit(governance); err != nil {
+				return err
+			}
+
+			if showRateLimit && verbosity >= verboseProgress {
+				printRateLimit(fullName)
+			}
+		}
+		return nil
+	}
+
+	if outputDir != "" {
+		err = inspectAll(newRepoReportWriter().write)
+	} else if policyOnly {
+		err = inspectAll(func(*GovernanceConfig) error { return nil })
+		if err == nil {
+			err = writeReport(func(w io.Writer) error {
+				return outputViolations(w, redactViolations(violations, redactCategories))
+			})
+		}
+	} else if strings.ToLower(outputFormat) == "ndjson" && reportTemplate == nil {
+		// Stream each record as soon as it is inspected instead of holding the batch
+		err = writeReport(func(w io.Writer) error {
+			return inspectAll(func(governance *GovernanceConfig) error {
+				return outputNDJSON(w, changedRulesets(redact(governance, redactCategories)))
+			})
+		})
+	} else {
+		var governances []*GovernanceConfig
+		err = inspectAll(func(governance *GovernanceConfig) error {
+			governances = append(governances, governance)
+			return nil
+		})
+		if err == nil {
+			err = writeReport(func(w io.Writer) error {
+				return outputGovernanceList(w, governances, sections)
+			})
+		}
+	}
+	if err != nil {
+		return err
+	}
+
+	return reportGates(cmd, violations, tripped)
+}
+
+// formatExtensions maps each --format to the file extension of its reports
+var formatExtensions = map[string]string{
+	"json":           "json",
+	"yaml":           "yaml",
+	"yml":            "yaml",
+	"toml":           "toml",
+	"xml":            "xml",
+	"table":          "txt",
+	"csv":            "csv",
+	"markdown":       "md",
+	"md":             "md",
+	"html":           "html",
+	"sarif":          "sarif",
+	"github-actions": "txt",
+	"ndjson":         "ndjson",
+	"summary":        "txt",
+	"summary-json":   "json",
+}
+
+// repoReportWriter writes each repository's report of a batch to its own
+// file in --output-dir
+type repoReportWriter struct {
+	written map[string]bool
+}
+
+func newRepoReportWriter() *repoReportWriter {
+	return &repoReportWriter{written: map[string]bool{}}
+}
+
+// write renders governance to <dir>/<owner>__<repo>.<ext>, creating the
+// directory on first use. A repository listed twice overwrites its report.
+func (rw *repoReportWriter) write(governance *GovernanceConfig) error {
+	if err := os.MkdirAll(outputDir, 0o755); err != nil {
+		return fmt.Errorf("failed to create output directory: %v", err)
+	}
+
+	extension, ok := formatExtensions[strings.ToLower(outputFormat)]
+	if !ok {
+		return fmt.Errorf("unsupported output format: %s", outputFormat)
+	}
+	path := filepath.Join(outputDir, fmt.Sprintf("%s__%s.%s", governance.Repository.Owner, governance.Repository.Name, extension))
+	fullName := governance.Repository.Owner + "/" + governance.Repository.Name
+	if rw.written[path] {
+		logger.Info(fmt.Sprintf("%s was inspected more than once, overwriting %s", fullName, path), slog.String("repo", fullName))
+	}
+	rw.written[path] = true
+
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create output file: %v", err)
+	}
+	if err := outputGovernance(file, governance, sections); err != nil {
+		file.Close()
+		return fmt.Errorf("failed to write output file: %v", err)
+	}
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("failed to write output file: %v", err)
+	}
+
+	logger.Info(fmt.Sprintf("Report written to %s", path), slog.String("repo", fullName))
+	return nil
+}
+
+// orgRepo is the part of an organization's repository listing we use
+type orgRepo struct {
+	Name          string `json:"name"`
+	DefaultBranch string `json:"default_branch"`
+	Fork          bool   `json:"fork"`
+	Archived      bool   `json:"archived"`
+}
+
+// listOrgRepos returns an organization's repositories, stopping once limit
+// repositories were collected when limit is positive. Forks and archived
+// repositories are skipped according to --include-forks and
+// --exclude-archived, using the flags on the list response itself.
+func listOrgRepos(client apiClient, org string, limit int) ([]orgRepo, error) {
+	var listed []orgRepo
+	for page := 1; ; page++ {
+		var repos []orgRepo
+
+		err := client.Get(fmt.Sprintf("orgs/%s/repos?per_page=%d&page=%d", org, perPage, page), &repos)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, repo := range repos {
+			if (repo.Fork && !includeForks) || (repo.Archived && excludeArchived) {
+				logger.Debug(fmt.Sprintf("Skipping %s/%s (fork: %t, archived: %t)", org, repo.Name, repo.Fork, repo.Archived))
+				continue
+			}
+			listed = append(listed, repo)
+			if limit > 0 && len(listed) >= limit {
+				return listed, nil
+			}
+		}
+
+		if len(repos) < perPage {
+			return listed, nil
+		}
+	}
+}
@@ -1,148 +1,1478 @@
 package main
 
 import (
+	"bytes"
+	"encoding/csv"
 	"encoding/json"
 	"fmt"
-	"os"
+	"io"
+	"slices"
+	"strconv"
 	"strings"
+	"time"
+	"unicode/utf8"
 
+	"github.com/BurntSushi/toml"
+	"github.com/cli/go-gh/v2/pkg/jq"
 	"github.com/jefeish/gh-repo-inspect/utils"
 	"gopkg.in/yaml.v3"
 )
 
-func outputGovernance(governance *GovernanceConfig, sectionsFilter []string) error {
+// errWriter remembers the first write error so formatters that print line by
+// line don't need to check every call
+type errWriter struct {
+	w   io.Writer
+	err error
+}
+
+func (ew *errWriter) Write(p []byte) (int, error) {
+	if ew.err != nil {
+		return 0, ew.err
+	}
+	n, err := ew.w.Write(p)
+	ew.err = err
+	return n, err
+}
+
+func outputGovernance(out io.Writer, governance *GovernanceConfig, sectionsFilter []string) error {
+	w := &errWriter{w: out}
+	governance = redact(governance, redactCategories)
+	if !evaluatesFailConditions(outputFormat) {
+		governance = changedRulesets(governance)
+	}
+
+	if reportTemplate != nil {
+		if err := outputTemplate(w, governance); err != nil {
+			return err
+		}
+		return w.err
+	}
+
+	var err error
 	switch strings.ToLower(outputFormat) {
 	case "json":
-		return outputJSON(governance)
+		var value interface{} = governance
+		if fieldSelection != nil {
+			value = selectFields(governance, fieldSelection)
+		}
+		if jqExpr != "" {
+			err = outputJQ(w, value, jqExpr)
+		} else {
+			err = outputJSON(w, value)
+		}
 	case "yaml", "yml":
-		return outputYAML(governance)
+		err = outputYAML(w, governance)
+	case "toml":
+		err = outputTOML(w, tomlDocument(governance, sectionsFilter))
+	case "xml":
+		err = outputXML(w, xmlDocument(governance, sectionsFilter))
 	case "table":
-		return outputTable(governance, sectionsFilter)
+		var tree io.Writer = w
+		var buf bytes.Buffer
+		if compact {
+			tree = &buf
+		}
+		if fieldSelection != nil {
+			err = outputFieldsTable(tree, governance, fieldSelection)
+		} else {
+			err = outputTable(tree, governance, sectionsFilter)
+		}
+		if compact && err == nil {
+			_, err = io.WriteString(w, utils.FlattenTree(buf.String()))
+		}
+	case "csv":
+		err = outputCSV(w, governance, sectionsFilter)
+	case "markdown", "md":
+		err = outputMarkdown(w, governance, sectionsFilter)
+	case "html":
+		err = outputHTML(w, []*GovernanceConfig{governance}, sectionsFilter)
+	case "sarif":
+		err = outputSARIF(w, []*GovernanceConfig{governance})
+	case "github-actions":
+		err = outputActionsAnnotations(w, []*GovernanceConfig{governance})
+	case "ndjson":
+		err = outputNDJSON(w, governance)
+	case "summary":
+		err = outputFleetSummary(w, []*GovernanceConfig{governance})
+	case "summary-json":
+		err = outputJSON(w, fleetRows([]*GovernanceConfig{governance}))
 	default:
 		return fmt.Errorf("unsupported output format: %s", outputFormat)
 	}
-}
 
-func outputJSON(governance *GovernanceConfig) error {
-	encoder := json.NewEncoder(os.Stdout)
-	encoder.SetIndent("", "  ")
-	return encoder.Encode(governance)
-}
+	if err != nil {
+		return err
+	}
+	return w.err
+}
+
+// outputGovernanceList renders a batch of reports, as a single array for
+// JSON/YAML, as a single document for HTML/SARIF/summary/TOML/XML and as one report after another
+// for the other formats and --template
+func outputGovernanceList(out io.Writer, governances []*GovernanceConfig, sectionsFilter []string) error {
+	if len(redactCategories) > 0 || !changedSinceTime.IsZero() {
+		rendered := make([]*GovernanceConfig, len(governances))
+		for i, governance := range governances {
+			rendered[i] = redact(governance, redactCategories)
+			if !evaluatesFailConditions(outputFormat) {
+				rendered[i] = changedRulesets(rendered[i])
+			}
+		}
+		governances = rendered
+	}
+
+	// --template renders each report on its own, like the text formats
+	format := strings.ToLower(outputFormat)
+	if reportTemplate != nil {
+		format = ""
+	}
+
+	switch format {
+	case "json", "yaml", "yml":
+		w := &errWriter{w: out}
+		var value interface{} = governances
+		if fieldSelection != nil {
+			selected := make([]map[string]interface{}, 0, len(governances))
+			for _, governance := range governances {
+				selected = append(selected, selectFields(governance, fieldSelection))
+			}
+			value = selected
+		}
+		var err error
+		if strings.ToLower(outputFormat) == "json" && jqExpr != "" {
+			err = outputJQ(w, value, jqExpr)
+		} else if strings.ToLower(outputFormat) == "json" {
+			err = outputJSON(w, value)
+		} else {
+			err = outputYAML(w, governances)
+		}
+		if err != nil {
+			return err
+		}
+		return w.err
+	case "ndjson":
+		w := &errWriter{w: out}
+		for _, governance := range governances {
+			if err := outputNDJSON(w, governance); err != nil {
+				return err
+			}
+		}
+		return w.err
+	case "html", "sarif", "github-actions", "summary", "summary-json", "toml", "xml":
+		// One document holding every report
+		w := &errWriter{w: out}
+		var err error
+		switch strings.ToLower(outputFormat) {
+		case "toml":
+			// TOML documents must be tables, so the reports become an
+			// array of tables under one key
+			reports := make([]map[string]interface{}, 0, len(governances))
+			for _, governance := range governances {
+				reports = append(reports, tomlDocument(governance, sectionsFilter))
+			}
+			err = outputTOML(w, map[string]interface{}{"repositories": reports})
+		case "xml":
+			reports := xmlReports{Reports: make([]xmlReport, 0, len(governances))}
+			for _, governance := range governances {
+				reports.Reports = append(reports.Reports, xmlDocument(governance, sectionsFilter))
+			}
+			err = outputXML(w, reports)
+		case "html":
+			err = outputHTML(w, governances, sectionsFilter)
+		case "sarif":
+			err = outputSARIF(w, governances)
+		case "github-actions":
+			err = outputActionsAnnotations(w, governances)
+		case "summary":
+			err = outputFleetSummary(w, governances)
+		default:
+			err = outputJSON(w, fleetRows(governances))
+		}
+		if err != nil {
+			return err
+		}
+		return w.err
+	}
+
+	for i, governance := range governances {
+		if i > 0 {
+			if _, err := fmt.Fprintln(out); err != nil {
+				return err
+			}
+		}
+		if err := outputGovernance(out, governance, sectionsFilter); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// changedRulesets returns a copy of governance that only lists the rulesets
+// updated after --changed-since. It is applied when rendering, so compliance,
+// --fail-on and the summary still see every ruleset. Classic branch
+// protection has no timestamp, so it can't be shown to have changed and is
+// dropped too.
+func changedRulesets(governance *GovernanceConfig) *GovernanceConfig {
+	if changedSinceTime.IsZero() {
+		return governance
+	}
+	filtered := *governance
+	filtered.Rulesets = nil
+	for _, ruleset := range governance.Rulesets {
+		updatedAt, err := time.Parse(time.RFC3339, ruleset.UpdatedAt)
+		if err == nil && updatedAt.After(changedSinceTime) {
+			filtered.Rulesets = append(filtered.Rulesets, ruleset)
+		}
+	}
+	return &filtered
+}
+
+// evaluatesFailConditions reports whether a format renders the --fail-on
+// conditions of each report, which must see the unfiltered rulesets
+func evaluatesFailConditions(format string) bool {
+	format = strings.ToLower(format)
+	return reportTemplate == nil && (format == "sarif" || format == "github-actions")
+}
+
+func outputJSON(w io.Writer, value interface{}) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(value)
+}
+
+// outputJQ filters the JSON encoding of value through a jq expression, printing
+// string results unquoted one per line like gh api --jq
+func outputJQ(w io.Writer, value interface{}, expr string) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	if err := jq.Evaluate(bytes.NewReader(data), w, expr); err != nil {
+		return fmt.Errorf("failed to evaluate --jq expression: %v", err)
+	}
+	return nil
+}
+
+// outputNDJSON writes value as a single unindented JSON line
+func outputNDJSON(w io.Writer, value interface{}) error {
+	return json.NewEncoder(w).Encode(value)
+}
+
+// outputViolations writes the --policy-only report, an empty array when the
+// repository is compliant
+func outputViolations(w io.Writer, violations []Violation) error {
+	if violations == nil {
+		violations = []Violation{}
+	}
+	return outputJSON(w, violations)
+}
+
+func outputYAML(w io.Writer, value interface{}) error {
+	encoder := yaml.NewEncoder(w)
+	encoder.SetIndent(yamlIndent)
+	if yamlFlow {
+		var node yaml.Node
+		if err := node.Encode(value); err != nil {
+			return err
+		}
+		flowScalarSequences(&node)
+		value = &node
+	}
+	if err := encoder.Encode(value); err != nil {
+		return err
+	}
+	return encoder.Close()
+}
+
+// flowScalarSequences switches lists of plain values, such as required status
+// checks or topics, to flow style ([ci, lint]); lists of objects stay in
+// block style so they remain readable
+func flowScalarSequences(node *yaml.Node) {
+	if node.Kind == yaml.SequenceNode && len(node.Content) > 0 {
+		scalars := true
+		for _, child := range node.Content {
+			if child.Kind != yaml.ScalarNode {
+				scalars = false
+				break
+			}
+		}
+		if scalars {
+			node.Style |= yaml.FlowStyle
+		}
+	}
+	for _, child := range node.Content {
+		flowScalarSequences(child)
+	}
+}
+
+// tomlDocument converts a report to the generic form of its JSON encoding so
+// TOML uses the same keys and omitempty behaviour. TOML has no null, so nil
+// values are dropped, and the always-present security settings are left out
+// when that section was filtered away.
+func tomlDocument(governance *GovernanceConfig, sectionsFilter []string) map[string]interface{} {
+	data, err := json.Marshal(governance)
+	if err != nil {
+		return nil
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var document map[string]interface{}
+	if err := decoder.Decode(&document); err != nil {
+		return nil
+	}
+
+	if !shouldIncludeSectionOutput("security", sectionsFilter) {
+		delete(document, "security_settings")
+	}
+	dropNulls(document)
+	return document
+}
+
+// dropNulls removes nil values from decoded JSON in place, recursing into
+// nested objects and arrays
+func dropNulls(value interface{}) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, item := range v {
+			if item == nil {
+				delete(v, key)
+				continue
+			}
+			dropNulls(item)
+		}
+	case []interface{}:
+		for _, item := range v {
+			dropNulls(item)
+		}
+	}
+}
+
+func outputTOML(w io.Writer, value interface{}) error {
+	return toml.NewEncoder(w).Encode(value)
+}
+
+func outputCSV(w io.Writer, governance *GovernanceConfig, sectionsFilter []string) error {
+	writer := csv.NewWriter(w)
+	wroteSection := false
+
+	// writeSection emits a header row followed by the data rows, separating
+	// consecutive sections with a blank line
+	writeSection := func(header []string, rows [][]string) error {
+		if wroteSection {
+			if err := writer.Write(nil); err != nil {
+				return err
+			}
+		}
+		wroteSection = true
+		if err := writer.Write(header); err != nil {
+			return err
+		}
+		return writer.WriteAll(rows)
+	}
+
+	if governance.Summary != nil && shouldIncludeSectionOutput("summary", sectionsFilter) {
+		summary := governance.Summary
+		rows := [][]string{
+			{"risk_score", strconv.Itoa(summary.RiskScore)},
+			{"ruleset_count", strconv.Itoa(summary.RulesetCount)},
+			{"protected_branch_count", strconv.Itoa(summary.ProtectedBranchCount)},
+			{"default_branch_protected", strconv.FormatBool(summary.DefaultBranchProtected)},
+			{"has_secret_scanning", strconv.FormatBool(summary.HasSecretScanning)},
+			{"collaborator_count", strconv.Itoa(summary.CollaboratorCount)},
+			{"admin_count", strconv.Itoa(summary.AdminCount)},
+			{"open_milestone_count", strconv.Itoa(summary.OpenMilestoneCount)},
+			{"bypassable_rulesets", strings.Join(summary.BypassableRulesets, ";")},
+			{"orphaned_checks", strings.Join(summary.OrphanedChecks, ";")},
+			{"rule_type_breakdown", ruleTypeText(summary.RuleTypeBreakdown, ";")},
+		}
+		if err := writeSection([]string{"key", "value"}, rows); err != nil {
+			return err
+		}
+	}
+
+	if shouldIncludeSectionOutput("settings", sectionsFilter) {
+		settings := governance.RepoSettings
+		rows := [][]string{
+			{"visibility", repoVisibility(settings)},
+			{"private", strconv.FormatBool(settings.Private)},
+			{"archived", strconv.FormatBool(settings.Archived)},
+			{"disabled", strconv.FormatBool(settings.Disabled)},
+			{"empty", strconv.FormatBool(settings.Empty)},
+			{"fork", strconv.FormatBool(settings.Fork)},
+			{"parent", settings.Parent},
+			{"source", settings.Source},
+			{"default_branch", settings.DefaultBranch},
+			{"allow_merge_commit", strconv.FormatBool(settings.AllowMergeCommit)},
+			{"allow_squash_merge", strconv.FormatBool(settings.AllowSquashMerge)},
+			{"allow_rebase_merge", strconv.FormatBool(settings.AllowRebaseMerge)},
+			{"allow_auto_merge", strconv.FormatBool(settings.AllowAutoMerge)},
+			{"delete_branch_on_merge", strconv.FormatBool(settings.DeleteBranchOnMerge)},
+			{"has_issues", strconv.FormatBool(settings.HasIssues)},
+			{"has_projects", strconv.FormatBool(settings.HasProjects)},
+			{"has_wiki", strconv.FormatBool(settings.HasWiki)},
+			{"has_downloads", strconv.FormatBool(settings.HasDownloads)},
+			{"topics", strings.Join(settings.Topics, ";")},
+			{"created_at", settings.CreatedAt},
+			{"updated_at", settings.UpdatedAt},
+			{"pushed_at", settings.PushedAt},
+			{"size_kb", strconv.Itoa(settings.SizeKB)},
+			{"open_issues", strconv.Itoa(settings.OpenIssues)},
+			{"forks", strconv.Itoa(settings.Forks)},
+			{"stargazers", strconv.Itoa(settings.Stargazers)},
+			{"watchers", strconv.Itoa(settings.Watchers)},
+			{"language", settings.Language},
+		}
+		for _, format := range mergeCommitFormats(settings) {
+			rows = append(rows, []string{format.key, format.value})
+		}
+		for _, property := range settings.CustomProperties {
+			rows = append(rows, []string{"custom_properties." + property.Key, property.Value})
+		}
+		if err := writeSection([]string{"key", "value"}, rows); err != nil {
+			return err
+		}
+	}
+
+	if shouldIncludeSectionOutput("security", sectionsFilter) {
+		security := governance.SecuritySettings
+		rows := [][]string{
+			{"vulnerability_alerts", strconv.FormatBool(security.VulnerabilityAlerts), securitySource(security, "vulnerability_alerts")},
+			{"automated_security_fixes", strconv.FormatBool(security.AutomatedSecurityFixes), securitySource(security, "automated_security_fixes")},
+			{"secret_scanning", strconv.FormatBool(security.SecretScanning), securitySource(security, "secret_scanning")},
+			{"secret_scanning_push_protection", strconv.FormatBool(security.SecretScanningPushProtection), securitySource(security, "secret_scanning_push_protection")},
+			{"dependency_graph_enabled", strconv.FormatBool(security.DependencyGraphEnabled), securitySource(security, "dependency_graph_enabled")},
+		}
+		if security.SecretScanningAlertCount != nil {
+			rows = append(rows,
+				[]string{"secret_scanning_alert_count", strconv.Itoa(*security.SecretScanningAlertCount), sourceRepo},
+				[]string{"dependabot_alert_count", strconv.Itoa(*security.DependabotAlertCount), sourceRepo})
+		}
+		if err := writeSection([]string{"key", "value", "source"}, rows); err != nil {
+			return err
+		}
+	}
+
+	if len(governance.Rulesets) > 0 && shouldIncludeSectionOutput("rulesets", sectionsFilter) {
+		header := []string{
+			"name", "pattern", "enforcement", "source", "enforce_admins", "required_status_checks", "required_pull_request_reviews",
+			"required_approving_review_count", "dismiss_stale_reviews", "require_code_owner_reviews",
+			"required_linear_history", "require_signed_commits", "allow_force_pushes", "allow_deletions", "required_conversation_resolution",
+			"merge_queue_enabled", "merge_queue_merge_method", "merge_queue_min_entries_to_merge", "merge_queue_max_entries_to_merge",
+			"ref_name_include", "ref_name_exclude", "bypass_actors",
+		}
+		var rows [][]string
+		for _, ruleset := range governance.Rulesets {
+			mergeQueue := MergeQueue{}
+			if ruleset.MergeQueue != nil {
+				mergeQueue = *ruleset.MergeQueue
+			}
+			rows = append(rows, []string{
+				ruleset.Name,
+				ruleset.Pattern,
+				ruleset.Enforcement,
+				ruleset.Source,
+				strconv.FormatBool(ruleset.EnforceAdmins),
+				strings.Join(ruleset.RequiredStatusChecks, ";"),
+				strconv.FormatBool(ruleset.RequiredPullRequestReviews),
+				strconv.Itoa(ruleset.RequiredApprovingReviewCount),
+				strconv.FormatBool(ruleset.DismissStaleReviews),
+				strconv.FormatBool(ruleset.RequireCodeOwnerReviews),
+				strconv.FormatBool(ruleset.RequiredLinearHistory),
+				strconv.FormatBool(ruleset.RequireSignedCommits),
+				strconv.FormatBool(ruleset.AllowForcePushes),
+				strconv.FormatBool(ruleset.AllowDeletions),
+				strconv.FormatBool(ruleset.RequiredConversationResolution),
+				strconv.FormatBool(ruleset.MergeQueueEnabled),
+				mergeQueue.MergeMethod,
+				strconv.Itoa(mergeQueue.MinEntriesToMerge),
+				strconv.Itoa(mergeQueue.MaxEntriesToMerge),
+				strings.Join(ruleset.RefNameInclude, ";"),
+				strings.Join(ruleset.RefNameExclude, ";"),
+				strings.Join(bypassActorStrings(ruleset.BypassActors), ";"),
+			})
+		}
+		if err := writeSection(header, rows); err != nil {
+			return err
+		}
+	}
+
+	if len(governance.Collaborators) > 0 && shouldIncludeSectionOutput("collaborators", sectionsFilter) {
+		var rows [][]string
+		for _, collab := range governance.Collaborators {
+			rows = append(rows, []string{collab.Login, collab.Permission, collab.Type, collab.Source, collab.RoleName, collab.LastCommitDate, strconv.FormatBool(collab.Inactive)})
+		}
+		if err := writeSection([]string{"login", "permission", "type", "source", "role_name", "last_commit_date", "inactive"}, rows); err != nil {
+			return err
+		}
+	}
+
+	if len(governance.Teams) > 0 && shouldIncludeSectionOutput("teams", sectionsFilter) {
+		var rows [][]string
+		for _, team := range governance.Teams {
+			rows = append(rows, []string{team.Name, team.Slug, team.Permission})
+		}
+		if err := writeSection([]string{"name", "slug", "permission"}, rows); err != nil {
+			return err
+		}
+	}
+
+	if len(governance.IssueLabels) > 0 && shouldIncludeSectionOutput("labels", sectionsFilter) {
+		var rows [][]string
+		for _, label := range governance.IssueLabels {
+			rows = append(rows, []string{label.Name, label.Color, label.Description})
+		}
+		if err := writeSection([]string{"name", "color", "description"}, rows); err != nil {
+			return err
+		}
+	}
+
+	if len(governance.Milestones) > 0 && shouldIncludeSectionOutput("milestones", sectionsFilter) {
+		var rows [][]string
+		for _, milestone := range governance.Milestones {
+			rows = append(rows, []string{milestone.Title, milestone.Description, milestone.State, milestone.DueOn, milestone.UpdatedAt})
+		}
+		if err := writeSection([]string{"title", "description", "state", "due_on", "updated_at"}, rows); err != nil {
+			return err
+		}
+	}
+
+	if len(governance.Webhooks) > 0 && shouldIncludeSectionOutput("webhooks", sectionsFilter) {
+		var rows [][]string
+		for _, hook := range governance.Webhooks {
+			rows = append(rows, []string{
+				strconv.Itoa(hook.ID),
+				hook.URL,
+				strings.Join(hook.Events, ";"),
+				strconv.FormatBool(hook.Active),
+				hook.ContentType,
+				strconv.FormatBool(hook.Secret),
+			})
+		}
+		if err := writeSection([]string{"id", "url", "events", "active", "content_type", "secret"}, rows); err != nil {
+			return err
+		}
+	}
+
+	if len(governance.Environments) > 0 && shouldIncludeSectionOutput("environments", sectionsFilter) {
+		var rows [][]string
+		for _, env := range governance.Environments {
+			rows = append(rows, []string{
+				env.Name,
+				strconv.Itoa(env.WaitTimer),
+				strings.Join(env.Reviewers, ";"),
+				env.DeploymentBranchPolicy,
+			})
+		}
+		if err := writeSection([]string{"name", "wait_timer", "reviewers", "deployment_branch_policy"}, rows); err != nil {
+			return err
+		}
+	}
+
+	if len(governance.ProtectedBranches) > 0 && shouldIncludeSectionOutput("branch-protection", sectionsFilter) {
+		var rows [][]string
+		for _, branch := range governance.ProtectedBranches {
+			rows = append(rows, []string{
+				branch.Name,
+				strconv.Itoa(branch.RequiredReviews),
+				strconv.FormatBool(branch.EnforceAdmins),
+				strconv.FormatBool(branch.RequireSignedCommits),
+				strings.Join(branch.Restrictions, ";"),
+			})
+		}
+		header := []string{"name", "required_reviews", "enforce_admins", "require_signed_commits", "restrictions"}
+		if err := writeSection(header, rows); err != nil {
+			return err
+		}
+	}
+
+	if len(governance.DeployKeys) > 0 && shouldIncludeSectionOutput("deploy-keys", sectionsFilter) {
+		var rows [][]string
+		for _, key := range governance.DeployKeys {
+			rows = append(rows, []string{
+				strconv.Itoa(key.ID),
+				key.Title,
+				strconv.FormatBool(key.ReadOnly),
+				key.CreatedAt,
+				key.LastUsed,
+			})
+		}
+		if err := writeSection([]string{"id", "title", "read_only", "created_at", "last_used"}, rows); err != nil {
+			return err
+		}
+	}
+
+	if governance.Actions != nil && shouldIncludeSectionOutput("actions", sectionsFilter) {
+		var rows [][]string
+		for _, name := range governance.Actions.SecretNames {
+			rows = append(rows, []string{"secret", name, ""})
+		}
+		for _, variable := range governance.Actions.Variables {
+			rows = append(rows, []string{"variable", variable.Key, variable.Value})
+		}
+		if err := writeSection([]string{"kind", "name", "value"}, rows); err != nil {
+			return err
+		}
+	}
+
+	if governance.CodeOwners != nil && shouldIncludeSectionOutput("codeowners", sectionsFilter) {
+		codeOwners := governance.CodeOwners
+		rows := [][]string{
+			{"exists", strconv.FormatBool(codeOwners.Exists)},
+			{"path", codeOwners.Path},
+			{"rule_count", strconv.Itoa(codeOwners.RuleCount)},
+			{"errors", strings.Join(codeOwners.Errors, ";")},
+		}
+		if err := writeSection([]string{"key", "value"}, rows); err != nil {
+			return err
+		}
+		if len(codeOwners.Entries) > 0 {
+			var entryRows [][]string
+			for _, entry := range codeOwners.Entries {
+				entryRows = append(entryRows, []string{entry.Pattern, strings.Join(entry.Owners, ";")})
+			}
+			if err := writeSection([]string{"pattern", "owners"}, entryRows); err != nil {
+				return err
+			}
+		}
+	}
+
+	if governance.Pages != nil && shouldIncludeSectionOutput("pages", sectionsFilter) {
+		pages := governance.Pages
+		rows := [][]string{
+			{"enabled", strconv.FormatBool(pages.Enabled)},
+			{"source_branch", pages.SourceBranch},
+			{"source_path", pages.SourcePath},
+			{"custom_domain", pages.CustomDomain},
+			{"https_enforced", strconv.FormatBool(pages.HTTPSEnforced)},
+			{"public", strconv.FormatBool(pages.Public)},
+		}
+		if err := writeSection([]string{"key", "value"}, rows); err != nil {
+			return err
+		}
+	}
+
+	if len(governance.Autolinks) > 0 && shouldIncludeSectionOutput("autolinks", sectionsFilter) {
+		var rows [][]string
+		for _, autolink := range governance.Autolinks {
+			rows = append(rows, []string{autolink.KeyPrefix, autolink.URLTemplate, strconv.FormatBool(autolink.IsAlphanumeric)})
+		}
+		if err := writeSection([]string{"key_prefix", "url_template", "is_alphanumeric"}, rows); err != nil {
+			return err
+		}
+	}
+
+	if governance.Workflows != nil && shouldIncludeSectionOutput("workflows", sectionsFilter) {
+		enabled := ""
+		if governance.Workflows.ActionsEnabled != nil {
+			enabled = strconv.FormatBool(*governance.Workflows.ActionsEnabled)
+		}
+		if err := writeSection([]string{"key", "value"}, [][]string{{"actions_enabled", enabled}}); err != nil {
+			return err
+		}
+		if len(governance.Workflows.Workflows) > 0 {
+			var rows [][]string
+			for _, workflow := range governance.Workflows.Workflows {
+				rows = append(rows, []string{workflow.Name, workflow.Path, workflow.State})
+			}
+			if err := writeSection([]string{"name", "path", "state"}, rows); err != nil {
+				return err
+			}
+		}
+	}
+
+	if governance.Dependabot != nil && shouldIncludeSectionOutput("dependabot", sectionsFilter) {
+		dependabot := governance.Dependabot
+		rows := [][]string{
+			{"exists", strconv.FormatBool(dependabot.Exists)},
+			{"path", dependabot.Path},
+			{"ecosystems", strings.Join(dependabot.Ecosystems, ";")},
+			{"schedules", strings.Join(dependabot.Schedules, ";")},
+		}
+		if err := writeSection([]string{"key", "value"}, rows); err != nil {
+			return err
+		}
+	}
+
+	if len(governance.Releases) > 0 && shouldIncludeSectionOutput("releases", sectionsFilter) {
+		var rows [][]string
+		for _, release := range governance.Releases {
+			rows = append(rows, []string{release.TagName, release.Name, strconv.FormatBool(release.Draft), strconv.FormatBool(release.Prerelease), release.PublishedAt})
+		}
+		if err := writeSection([]string{"tag_name", "name", "draft", "prerelease", "published_at"}, rows); err != nil {
+			return err
+		}
+	}
+
+	if len(governance.TagProtections) > 0 && shouldIncludeSectionOutput("releases", sectionsFilter) {
+		var rows [][]string
+		for _, protection := range governance.TagProtections {
+			rows = append(rows, []string{protection.Pattern})
+		}
+		if err := writeSection([]string{"tag_protection_pattern"}, rows); err != nil {
+			return err
+		}
+	}
+
+	if governance.Templates != nil && shouldIncludeSectionOutput("templates", sectionsFilter) {
+		templates := governance.Templates
+		rows := [][]string{
+			{"issue_templates", strings.Join(templates.IssueTemplates, ";")},
+			{"has_pr_template", strconv.FormatBool(templates.HasPRTemplate)},
+			{"has_config_yml", strconv.FormatBool(templates.HasConfigYML)},
+		}
+		if err := writeSection([]string{"key", "value"}, rows); err != nil {
+			return err
+		}
+	}
+
+	if len(governance.Branches) > 0 && shouldIncludeSectionOutput("branches", sectionsFilter) {
+		var rows [][]string
+		for _, branch := range governance.Branches {
+			rows = append(rows, []string{
+				branch.Name,
+				strconv.FormatBool(branch.Protected),
+				strconv.Itoa(branch.AheadBy),
+				strconv.Itoa(branch.BehindBy),
+				branch.LastCommitDate,
+				strconv.FormatBool(branch.Stale),
+			})
+		}
+		if err := writeSection([]string{"name", "protected", "ahead_by", "behind_by", "last_commit_date", "stale"}, rows); err != nil {
+			return err
+		}
+	}
+
+	if len(governance.TagRules) > 0 && shouldIncludeSectionOutput("tag-rules", sectionsFilter) {
+		var rows [][]string
+		for _, rule := range governance.TagRules {
+			rows = append(rows, []string{rule.Name, rule.Pattern, rule.Enforcement})
+		}
+		if err := writeSection([]string{"name", "pattern", "enforcement"}, rows); err != nil {
+			return err
+		}
+	}
+
+	if governance.CommunityFiles != nil && shouldIncludeSectionOutput("community-files", sectionsFilter) {
+		community := governance.CommunityFiles
+		rows := [][]string{
+			{"health_percentage", strconv.Itoa(community.HealthPercentage)},
+			{"has_license", strconv.FormatBool(community.HasLicense)},
+			{"license", community.License},
+			{"has_contributing", strconv.FormatBool(community.HasContributing)},
+			{"has_code_of_conduct", strconv.FormatBool(community.HasCodeOfConduct)},
+			{"has_security_policy", strconv.FormatBool(community.HasSecurityPolicy)},
+			{"has_readme", strconv.FormatBool(community.HasReadme)},
+		}
+		if err := writeSection([]string{"key", "value"}, rows); err != nil {
+			return err
+		}
+	}
+
+	if len(governance.RecentEvents) > 0 && shouldIncludeSectionOutput("recent-events", sectionsFilter) {
+		var rows [][]string
+		for _, event := range governance.RecentEvents {
+			rows = append(rows, []string{event.CreatedAt, event.Type, event.Actor, event.Detail})
+		}
+		if err := writeSection([]string{"created_at", "event", "actor", "detail"}, rows); err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+func outputMarkdown(w io.Writer, governance *GovernanceConfig, sectionsFilter []string) error {
+	fmt.Fprintf(w, "# %s/%s\n\n", governance.Repository.Owner, governance.Repository.Name)
+
+	// Summary
+	if governance.Summary != nil && shouldIncludeSectionOutput("summary", sectionsFilter) {
+		summary := governance.Summary
+		fmt.Fprintf(w, "## 📊 Summary\n\n")
+		fmt.Fprintf(w, "| Metric | Value |\n")
+		fmt.Fprintf(w, "|--------|-------|\n")
+		fmt.Fprintf(w, "| Risk Score | %d/100 |\n", summary.RiskScore)
+		fmt.Fprintf(w, "| Rulesets | %d |\n", summary.RulesetCount)
+		fmt.Fprintf(w, "| Protected Branches | %d |\n", summary.ProtectedBranchCount)
+		fmt.Fprintf(w, "| Default Branch Protected | %s |\n", boolToIcon(summary.DefaultBranchProtected))
+		fmt.Fprintf(w, "| Secret Scanning | %s |\n", boolToIcon(summary.HasSecretScanning))
+		fmt.Fprintf(w, "| Collaborators | %d (%d admin) |\n", summary.CollaboratorCount, summary.AdminCount)
+		fmt.Fprintf(w, "| Open Milestones | %d |\n", summary.OpenMilestoneCount)
+		if len(summary.BypassableRulesets) > 0 {
+			fmt.Fprintf(w, "| ⚠️ Rulesets With Bypass Actors | %s |\n", markdownCell(strings.Join(summary.BypassableRulesets, ", ")))
+		}
+		if len(summary.OrphanedChecks) > 0 {
+			fmt.Fprintf(w, "| ⚠️ Potentially Orphaned Checks | %s |\n", markdownCell(strings.Join(summary.OrphanedChecks, ", ")))
+		}
+		if len(summary.RuleTypeBreakdown) > 0 {
+			fmt.Fprintf(w, "| Rule Types | %s |\n", markdownCell(ruleTypeText(summary.RuleTypeBreakdown, ", ")))
+		}
+		fmt.Fprintln(w)
+	}
+
+	// Repository Settings
+	if shouldIncludeSectionOutput("settings", sectionsFilter) {
+		settings := governance.RepoSettings
+		fmt.Fprintf(w, "## ⚙️ Repository Settings\n\n")
+		fmt.Fprintf(w, "| Setting | Value |\n")
+		fmt.Fprintf(w, "|---------|-------|\n")
+		fmt.Fprintf(w, "| Visibility | %s |\n", repoVisibility(settings))
+		fmt.Fprintf(w, "| Archived | %s |\n", boolToIcon(settings.Archived))
+		if settings.Empty {
+			fmt.Fprintf(w, "| Empty | ✅ no commits yet |\n")
+		}
+		if settings.Fork {
+			fmt.Fprintf(w, "| Fork Of | %s |\n", markdownCell(forkText(settings)))
+		}
+		fmt.Fprintf(w, "| Default Branch | %s |\n", markdownCell(settings.DefaultBranch))
+		if settings.CreatedAt != "" {
+			fmt.Fprintf(w, "| Created | %s |\n", settings.CreatedAt)
+		}
+		if settings.PushedAt != "" {
+			fmt.Fprintf(w, "| Last Push | %s |\n", settings.PushedAt)
+		}
+		fmt.Fprintf(w, "| Issues | %s |\n", boolToIcon(settings.HasIssues))
+		fmt.Fprintf(w, "| Projects | %s |\n", boolToIcon(settings.HasProjects))
+		fmt.Fprintf(w, "| Wiki | %s |\n", boolToIcon(settings.HasWiki))
+		fmt.Fprintf(w, "| Allow Merge Commit | %s |\n", boolToIcon(settings.AllowMergeCommit))
+		fmt.Fprintf(w, "| Allow Squash Merge | %s |\n", boolToIcon(settings.AllowSquashMerge))
+		fmt.Fprintf(w, "| Allow Rebase Merge | %s |\n", boolToIcon(settings.AllowRebaseMerge))
+		fmt.Fprintf(w, "| Delete Branch on Merge | %s |\n", boolToIcon(settings.DeleteBranchOnMerge))
+		for _, format := range mergeCommitFormats(settings) {
+			fmt.Fprintf(w, "| %s | `%s` |\n", format.label, format.value)
+		}
+		fmt.Fprintf(w, "| Topics | %s |\n", markdownCell(strings.Join(settings.Topics, ", ")))
+		for _, property := range settings.CustomProperties {
+			fmt.Fprintf(w, "| Custom Property: %s | %s |\n", markdownCell(property.Key), markdownCell(property.Value))
+		}
+		fmt.Fprintln(w)
+	}
+
+	// Security Settings
+	if shouldIncludeSectionOutput("security", sectionsFilter) {
+		security := governance.SecuritySettings
+		fmt.Fprintf(w, "## 🔒 Security Settings\n\n")
+		fmt.Fprintf(w, "| Setting | Value |\n")
+		fmt.Fprintf(w, "|---------|-------|\n")
+		fmt.Fprintf(w, "| Vulnerability Alerts | %s |\n", securityValue(security, "vulnerability_alerts", security.VulnerabilityAlerts))
+		fmt.Fprintf(w, "| Automated Security Fixes | %s |\n", securityValue(security, "automated_security_fixes", security.AutomatedSecurityFixes))
+		fmt.Fprintf(w, "| Secret Scanning | %s |\n", securityValue(security, "secret_scanning", security.SecretScanning))
+		fmt.Fprintf(w, "| Secret Scanning Push Protection | %s |\n", securityValue(security, "secret_scanning_push_protection", security.SecretScanningPushProtection))
+		fmt.Fprintf(w, "| Dependency Graph | %s |\n", securityValue(security, "dependency_graph_enabled", security.DependencyGraphEnabled))
+		if security.SecretScanningAlertCount != nil {
+			fmt.Fprintf(w, "| Open Secret Scanning Alerts | %s |\n", alertCount(security.SecretScanningAlertCount))
+			fmt.Fprintf(w, "| Open Dependabot Alerts | %s |\n", alertCount(security.DependabotAlertCount))
+		}
+		fmt.Fprintln(w)
+	}
+
+	// Repository Rulesets
+	if len(governance.Rulesets) > 0 && shouldIncludeSectionOutput("rulesets", sectionsFilter) {
+		fmt.Fprintf(w, "## 📜 Repository Rulesets\n\n")
+		fmt.Fprintf(w, "| Name | Applies To | Enforcement | Source | Enforce Admins | Require PR Reviews | Approvals | Linear History | Signed Commits | Force Pushes | Deletions | Merge Queue | Status Checks | Bypass Actors |\n")
+		fmt.Fprintf(w, "|------|---------|-------------|--------|----------------|--------------------|-----------|----------------|----------------|--------------|-----------|-------------|---------------|---------------|\n")
+		for _, ruleset := range governance.Rulesets {
+			checks := "None"
+			if len(ruleset.RequiredStatusChecks) > 0 {
+				checks = strings.Join(ruleset.RequiredStatusChecks, ", ")
+			}
+			bypassActors := "None"
+			if len(ruleset.BypassActors) > 0 {
+				bypassActors = strings.Join(bypassActorStrings(ruleset.BypassActors), ", ")
+			}
+			fmt.Fprintf(w, "| %s | %s | %s | %s | %s | %s | %d | %s | %s | %s | %s | %s | %s | %s |\n",
+				markdownCell(ruleset.Name),
+				markdownCell(refTargetText(ruleset)),
+				ruleset.Enforcement,
+				ruleset.Source,
+				boolToIcon(ruleset.EnforceAdmins),
+				boolToIcon(ruleset.RequiredPullRequestReviews),
+				ruleset.RequiredApprovingReviewCount,
+				boolToIcon(ruleset.RequiredLinearHistory),
+				boolToIcon(ruleset.RequireSignedCommits),
+				boolToIcon(ruleset.AllowForcePushes),
+				boolToIcon(ruleset.AllowDeletions),
+				mergeQueueText(ruleset.MergeQueue),
+				markdownCell(checks),
+				markdownCell(bypassActors))
+		}
+		fmt.Fprintln(w)
+	}
+
+	// Collaborators
+	if len(governance.Collaborators) > 0 && shouldIncludeSectionOutput("collaborators", sectionsFilter) {
+		fmt.Fprintf(w, "## 👥 Collaborators (%d)\n\n", len(governance.Collaborators))
+		header, divider := "| Login | Type | Permission |", "|-------|------|------------|"
+		if resolvePermissions {
+			header, divider = header+" Role | Source |", divider+"------|--------|"
+		}
+		if withActivity {
+			header, divider = header+" Last Commit | Inactive |", divider+"-------------|----------|"
+		}
+		fmt.Fprintf(w, "%s\n%s\n", header, divider)
+		for _, collab := range governance.Collaborators {
+			fmt.Fprintf(w, "| %s | %s | %s |", markdownCell(collab.Login), markdownCell(collab.Type), permissionToIcon(collab.Permission))
+			if resolvePermissions {
+				fmt.Fprintf(w, " %s | %s |", markdownCell(collab.RoleName), collab.Source)
+			}
+			if withActivity {
+				fmt.Fprintf(w, " %s | %s |", collab.LastCommitDate, boolToIcon(collab.Inactive))
+			}
+			fmt.Fprintln(w)
+		}
+		fmt.Fprintln(w)
+	}
+
+	// Teams
+	if len(governance.Teams) > 0 && shouldIncludeSectionOutput("teams", sectionsFilter) {
+		fmt.Fprintf(w, "## Teams (%d)\n\n", len(governance.Teams))
+		fmt.Fprintf(w, "| Name | Slug | Permission |\n")
+		fmt.Fprintf(w, "|------|------|------------|\n")
+		for _, team := range governance.Teams {
+			fmt.Fprintf(w, "| %s | @%s | %s |\n", markdownCell(team.Name), markdownCell(team.Slug), permissionToIcon(team.Permission))
+		}
+		fmt.Fprintln(w)
+	}
+
+	// Labels
+	if len(governance.IssueLabels) > 0 && shouldIncludeSectionOutput("labels", sectionsFilter) {
+		fmt.Fprintf(w, "## 🏷️ Labels (%d)\n\n", len(governance.IssueLabels))
+		fmt.Fprintf(w, "| Name | Color | Description |\n")
+		fmt.Fprintf(w, "|------|-------|-------------|\n")
+		for _, label := range governance.IssueLabels {
+			fmt.Fprintf(w, "| %s | #%s | %s |\n", markdownCell(label.Name), label.Color, markdownCell(label.Description))
+		}
+		fmt.Fprintln(w)
+	}
+
+	// Milestones
+	if len(governance.Milestones) > 0 && shouldIncludeSectionOutput("milestones", sectionsFilter) {
+		fmt.Fprintf(w, "## 🎯 Milestones (%d)\n\n", len(governance.Milestones))
+		fmt.Fprintf(w, "| Title | State | Due | Description |\n")
+		fmt.Fprintf(w, "|-------|-------|-----|-------------|\n")
+		for _, milestone := range governance.Milestones {
+			fmt.Fprintf(w, "| %s | %s | %s | %s |\n",
+				markdownCell(milestone.Title),
+				milestone.State,
+				milestone.DueOn,
+				markdownCell(milestone.Description))
+		}
+		fmt.Fprintln(w)
+	}
+
+	// Webhooks
+	if len(governance.Webhooks) > 0 && shouldIncludeSectionOutput("webhooks", sectionsFilter) {
+		fmt.Fprintf(w, "## 🪝 Webhooks (%d)\n\n", len(governance.Webhooks))
+		fmt.Fprintf(w, "| URL | Events | Active | Content Type | Secret |\n")
+		fmt.Fprintf(w, "|-----|--------|--------|--------------|--------|\n")
+		for _, hook := range governance.Webhooks {
+			fmt.Fprintf(w, "| %s | %s | %s | %s | %s |\n",
+				markdownCell(hook.URL),
+				markdownCell(strings.Join(hook.Events, ", ")),
+				boolToIcon(hook.Active),
+				hook.ContentType,
+				boolToIcon(hook.Secret))
+		}
+		fmt.Fprintln(w)
+	}
+
+	// Environments
+	if len(governance.Environments) > 0 && shouldIncludeSectionOutput("environments", sectionsFilter) {
+		fmt.Fprintf(w, "## 🚀 Environments (%d)\n\n", len(governance.Environments))
+		fmt.Fprintf(w, "| Name | Wait Timer | Reviewers | Deployment Branches |\n")
+		fmt.Fprintf(w, "|------|------------|-----------|---------------------|\n")
+		for _, env := range governance.Environments {
+			reviewers := "None"
+			if len(env.Reviewers) > 0 {
+				reviewers = strings.Join(env.Reviewers, ", ")
+			}
+			fmt.Fprintf(w, "| %s | %d min | %s | %s |\n",
+				markdownCell(env.Name),
+				env.WaitTimer,
+				markdownCell(reviewers),
+				env.DeploymentBranchPolicy)
+		}
+		fmt.Fprintln(w)
+	}
+
+	// Branch Protection
+	if len(governance.ProtectedBranches) > 0 && shouldIncludeSectionOutput("branch-protection", sectionsFilter) {
+		fmt.Fprintf(w, "## 🛡️ Branch Protection (%d)\n\n", len(governance.ProtectedBranches))
+		fmt.Fprintf(w, "| Branch | Required Reviews | Enforce Admins | Signed Commits | Push Restrictions |\n")
+		fmt.Fprintf(w, "|--------|------------------|----------------|----------------|-------------------|\n")
+		for _, branch := range governance.ProtectedBranches {
+			restrictions := "None"
+			if len(branch.Restrictions) > 0 {
+				restrictions = strings.Join(branch.Restrictions, ", ")
+			}
+			fmt.Fprintf(w, "| %s | %d | %s | %s | %s |\n",
+				markdownCell(branch.Name),
+				branch.RequiredReviews,
+				boolToIcon(branch.EnforceAdmins),
+				boolToIcon(branch.RequireSignedCommits),
+				markdownCell(restrictions))
+		}
+		fmt.Fprintln(w)
+	}
+
+	// Deploy Keys
+	if len(governance.DeployKeys) > 0 && shouldIncludeSectionOutput("deploy-keys", sectionsFilter) {
+		fmt.Fprintf(w, "## 🔑 Deploy Keys (%d)\n\n", len(governance.DeployKeys))
+		fmt.Fprintf(w, "| Title | Read Only | Created | Last Used |\n")
+		fmt.Fprintf(w, "|-------|-----------|---------|-----------|\n")
+		for _, key := range governance.DeployKeys {
+			lastUsed := "Never"
+			if key.LastUsed != "" {
+				lastUsed = key.LastUsed
+			}
+			fmt.Fprintf(w, "| %s | %s | %s | %s |\n", markdownCell(key.Title), boolToIcon(key.ReadOnly), key.CreatedAt, lastUsed)
+		}
+		fmt.Fprintln(w)
+	}
+
+	// Actions
+	if governance.Actions != nil && shouldIncludeSectionOutput("actions", sectionsFilter) {
+		fmt.Fprintf(w, "## ⚡ Actions Secrets and Variables\n\n")
+		fmt.Fprintf(w, "| Kind | Name | Value |\n")
+		fmt.Fprintf(w, "|------|------|-------|\n")
+		for _, name := range governance.Actions.SecretNames {
+			fmt.Fprintf(w, "| Secret | %s | *hidden* |\n", markdownCell(name))
+		}
+		for _, variable := range governance.Actions.Variables {
+			fmt.Fprintf(w, "| Variable | %s | %s |\n", markdownCell(variable.Key), markdownCell(variable.Value))
+		}
+		fmt.Fprintln(w)
+	}
+
+	// CODEOWNERS
+	if governance.CodeOwners != nil && shouldIncludeSectionOutput("codeowners", sectionsFilter) {
+		codeOwners := governance.CodeOwners
+		fmt.Fprintf(w, "## 📋 CODEOWNERS\n\n")
+		if !codeOwners.Exists {
+			fmt.Fprintf(w, "No CODEOWNERS file found.\n\n")
+		} else {
+			fmt.Fprintf(w, "Found at `%s` with %d rule(s).\n\n", codeOwners.Path, codeOwners.RuleCount)
+			fmt.Fprintf(w, "| Pattern | Owners |\n")
+			fmt.Fprintf(w, "|---------|--------|\n")
+			for _, entry := range codeOwners.Entries {
+				fmt.Fprintf(w, "| `%s` | %s |\n", markdownCell(entry.Pattern), markdownCell(strings.Join(entry.Owners, ", ")))
+			}
+			fmt.Fprintln(w)
+			for _, codeOwnersErr := range codeOwners.Errors {
+				fmt.Fprintf(w, "- ⚠️ %s\n", markdownCell(codeOwnersErr))
+			}
+			if len(codeOwners.Errors) > 0 {
+				fmt.Fprintln(w)
+			}
+		}
+	}
+
+	// Pages
+	if governance.Pages != nil && shouldIncludeSectionOutput("pages", sectionsFilter) {
+		pages := governance.Pages
+		fmt.Fprintf(w, "## 🌐 GitHub Pages\n\n")
+		if !pages.Enabled {
+			fmt.Fprintf(w, "GitHub Pages is not enabled.\n\n")
+		} else {
+			fmt.Fprintf(w, "| Setting | Value |\n")
+			fmt.Fprintf(w, "|---------|-------|\n")
+			fmt.Fprintf(w, "| Source | `%s` %s |\n", markdownCell(pages.SourceBranch), markdownCell(pages.SourcePath))
+			if pages.CustomDomain != "" {
+				fmt.Fprintf(w, "| Custom Domain | %s |\n", markdownCell(pages.CustomDomain))
+			}
+			fmt.Fprintf(w, "| HTTPS Enforced | %s |\n", boolToIcon(pages.HTTPSEnforced))
+			fmt.Fprintf(w, "| Public | %s |\n", boolToIcon(pages.Public))
+			fmt.Fprintln(w)
+		}
+	}
+
+	// Autolinks
+	if len(governance.Autolinks) > 0 && shouldIncludeSectionOutput("autolinks", sectionsFilter) {
+		fmt.Fprintf(w, "## 🔗 Autolinks (%d)\n\n", len(governance.Autolinks))
+		fmt.Fprintf(w, "| Key Prefix | URL Template | Alphanumeric |\n")
+		fmt.Fprintf(w, "|------------|--------------|--------------|\n")
+		for _, autolink := range governance.Autolinks {
+			fmt.Fprintf(w, "| `%s` | %s | %s |\n", markdownCell(autolink.KeyPrefix), markdownCell(autolink.URLTemplate), boolToIcon(autolink.IsAlphanumeric))
+		}
+		fmt.Fprintln(w)
+	}
+
+	// Workflows
+	if governance.Workflows != nil && shouldIncludeSectionOutput("workflows", sectionsFilter) {
+		workflows := governance.Workflows
+		fmt.Fprintf(w, "## ⚡ Workflows (%d)\n\n", len(workflows.Workflows))
+		fmt.Fprintf(w, "Actions enabled: %s\n\n", actionsEnabledText(workflows.ActionsEnabled))
+		if workflows.ActionsEnabled != nil && !*workflows.ActionsEnabled {
+			fmt.Fprintf(w, "GitHub Actions is disabled, so workflow files are not listed.\n\n")
+		} else if len(workflows.Workflows) > 0 {
+			fmt.Fprintf(w, "| Name | Path | State |\n")
+			fmt.Fprintf(w, "|------|------|-------|\n")
+			for _, workflow := range workflows.Workflows {
+				fmt.Fprintf(w, "| %s | `%s` | %s |\n", markdownCell(workflow.Name), markdownCell(workflow.Path), markdownCell(workflow.State))
+			}
+			fmt.Fprintln(w)
+		}
+	}
+
+	// Dependabot
+	if governance.Dependabot != nil && shouldIncludeSectionOutput("dependabot", sectionsFilter) {
+		dependabot := governance.Dependabot
+		fmt.Fprintf(w, "## 🤖 Dependabot\n\n")
+		if !dependabot.Exists {
+			fmt.Fprintf(w, "No Dependabot configuration found.\n\n")
+		} else {
+			fmt.Fprintf(w, "Found at `%s` with %d update configuration(s).\n\n", dependabot.Path, len(dependabot.Ecosystems))
+			if len(dependabot.Ecosystems) > 0 {
+				fmt.Fprintf(w, "| Ecosystem | Schedule |\n")
+				fmt.Fprintf(w, "|-----------|----------|\n")
+				for i, ecosystem := range dependabot.Ecosystems {
+					fmt.Fprintf(w, "| %s | %s |\n", markdownCell(ecosystem), markdownCell(dependabot.Schedules[i]))
+				}
+				fmt.Fprintln(w)
+			}
+		}
+	}
+
+	// Releases and tag protection
+	if shouldIncludeSectionOutput("releases", sectionsFilter) && (len(governance.Releases) > 0 || len(governance.TagProtections) > 0) {
+		fmt.Fprintf(w, "## 🚀 Releases (%d)\n\n", len(governance.Releases))
+		if len(governance.Releases) > 0 {
+			fmt.Fprintf(w, "| Tag | Name | Draft | Prerelease | Published |\n")
+			fmt.Fprintf(w, "|-----|------|-------|------------|-----------|\n")
+			for _, release := range governance.Releases {
+				fmt.Fprintf(w, "| `%s` | %s | %s | %s | %s |\n",
+					markdownCell(release.TagName),
+					markdownCell(release.Name),
+					boolToIcon(release.Draft),
+					boolToIcon(release.Prerelease),
+					release.PublishedAt)
+			}
+			fmt.Fprintln(w)
+		}
+		if len(governance.TagProtections) > 0 {
+			fmt.Fprintf(w, "Protected tag patterns:\n\n")
+			for _, protection := range governance.TagProtections {
+				fmt.Fprintf(w, "- `%s`\n", markdownCell(protection.Pattern))
+			}
+			fmt.Fprintln(w)
+		}
+	}
+
+	// Templates
+	if governance.Templates != nil && shouldIncludeSectionOutput("templates", sectionsFilter) {
+		templates := governance.Templates
+		fmt.Fprintf(w, "## 📝 Issue & PR Templates\n\n")
+		fmt.Fprintf(w, "| Setting | Value |\n")
+		fmt.Fprintf(w, "|---------|-------|\n")
+		fmt.Fprintf(w, "| PR Template | %s |\n", boolToIcon(templates.HasPRTemplate))
+		fmt.Fprintf(w, "| Template Chooser Config | %s |\n", boolToIcon(templates.HasConfigYML))
+		fmt.Fprintf(w, "| Issue Templates | %d |\n\n", len(templates.IssueTemplates))
+		for _, path := range templates.IssueTemplates {
+			fmt.Fprintf(w, "- `%s`\n", markdownCell(path))
+		}
+		if len(templates.IssueTemplates) > 0 {
+			fmt.Fprintln(w)
+		}
+	}
+
+	// Branches
+	if len(governance.Branches) > 0 && shouldIncludeSectionOutput("branches", sectionsFilter) {
+		fmt.Fprintf(w, "## 🌿 Branches (%d)\n\n", len(governance.Branches))
+		fmt.Fprintf(w, "| Branch | Protected | Ahead | Behind | Last Commit | Stale |\n")
+		fmt.Fprintf(w, "|--------|-----------|-------|--------|-------------|-------|\n")
+		for _, branch := range governance.Branches {
+			fmt.Fprintf(w, "| `%s` | %s | %d | %d | %s | %s |\n",
+				markdownCell(branch.Name),
+				boolToIcon(branch.Protected),
+				branch.AheadBy,
+				branch.BehindBy,
+				branch.LastCommitDate,
+				boolToIcon(branch.Stale))
+		}
+		fmt.Fprintln(w)
+	}
+
+	// Tag rulesets
+	if len(governance.TagRules) > 0 && shouldIncludeSectionOutput("tag-rules", sectionsFilter) {
+		fmt.Fprintf(w, "## 🔖 Tag Rulesets (%d)\n\n", len(governance.TagRules))
+		fmt.Fprintf(w, "| Name | Pattern | Enforcement |\n")
+		fmt.Fprintf(w, "|------|---------|-------------|\n")
+		for _, rule := range governance.TagRules {
+			fmt.Fprintf(w, "| %s | `%s` | %s |\n", markdownCell(rule.Name), markdownCell(rule.Pattern), rule.Enforcement)
+		}
+		fmt.Fprintln(w)
+	}
+
+	// Community files
+	if governance.CommunityFiles != nil && shouldIncludeSectionOutput("community-files", sectionsFilter) {
+		community := governance.CommunityFiles
+		fmt.Fprintf(w, "## 🤝 Community Files\n\n")
+		fmt.Fprintf(w, "| Setting | Value |\n")
+		fmt.Fprintf(w, "|---------|-------|\n")
+		fmt.Fprintf(w, "| Health | %d%% |\n", community.HealthPercentage)
+		fmt.Fprintf(w, "| License | %s |\n", communityLicense(community))
+		fmt.Fprintf(w, "| Contributing | %s |\n", boolToIcon(community.HasContributing))
+		fmt.Fprintf(w, "| Code of Conduct | %s |\n", boolToIcon(community.HasCodeOfConduct))
+		fmt.Fprintf(w, "| Security Policy | %s |\n", boolToIcon(community.HasSecurityPolicy))
+		fmt.Fprintf(w, "| README | %s |\n\n", boolToIcon(community.HasReadme))
+	}
+
+	// Recent governance events
+	if len(governance.RecentEvents) > 0 && shouldIncludeSectionOutput("recent-events", sectionsFilter) {
+		fmt.Fprintf(w, "## 📜 Recent Events (%d)\n\n", len(governance.RecentEvents))
+		fmt.Fprintf(w, "| Date | Event | Actor | Detail |\n")
+		fmt.Fprintf(w, "|------|-------|-------|--------|\n")
+		for _, event := range governance.RecentEvents {
+			fmt.Fprintf(w, "| %s | %s | @%s | %s |\n", event.CreatedAt, event.Type, markdownCell(event.Actor), markdownCell(event.Detail))
+		}
+		fmt.Fprintln(w)
+	}
 
-func outputYAML(governance *GovernanceConfig) error {
-	encoder := yaml.NewEncoder(os.Stdout)
-	defer encoder.Close()
-	return encoder.Encode(governance)
+	return nil
 }
 
-func outputTable(governance *GovernanceConfig, sectionsFilter []string) error {
-	fmt.Printf("Repository Governance Report\n")
-	fmt.Printf("═══════════════════════════\n\n")
+func outputTable(w io.Writer, governance *GovernanceConfig, sectionsFilter []string) error {
+	fmt.Fprintf(w, "%s\n", msg("Repository Governance Report"))
+	fmt.Fprintf(w, "═══════════════════════════\n\n")
 
 	// Repository Information
-	fmt.Printf("📁 Repository: %s/%s\n\n", governance.Repository.Owner, governance.Repository.Name)
+	fmt.Fprintf(w, "%s%s: %s/%s\n\n", icon("📁 "), msg("Repository"), governance.Repository.Owner, governance.Repository.Name)
+
+	// Summary
+	if governance.Summary != nil && shouldIncludeSectionOutput("summary", sectionsFilter) {
+		summary := governance.Summary
+		fmt.Fprintf(w, "%s%s\n", icon("📊 "), msg("Summary"))
+		fmt.Fprintf(w, "├─ %s: %d/100\n", msg("Risk Score"), summary.RiskScore)
+		fmt.Fprintf(w, "├─ %s: %d\n", msg("Rulesets"), summary.RulesetCount)
+		fmt.Fprintf(w, "├─ %s: %d\n", msg("Protected Branches"), summary.ProtectedBranchCount)
+		fmt.Fprintf(w, "├─ %s: %s\n", msg("Default Branch Protected"), boolToIcon(summary.DefaultBranchProtected))
+		fmt.Fprintf(w, "├─ %s: %s\n", msg("Secret Scanning"), boolToIcon(summary.HasSecretScanning))
+		fmt.Fprintf(w, "├─ %s: %d (%d %s)\n", msg("Collaborators"), summary.CollaboratorCount, summary.AdminCount, msg("admin"))
+		lines := []string{fmt.Sprintf("%s: %d", msg("Open Milestones"), summary.OpenMilestoneCount)}
+		if len(summary.RuleTypeBreakdown) > 0 {
+			lines = append(lines, fmt.Sprintf("%s: %s", msg("Rule Types"), ruleTypeText(summary.RuleTypeBreakdown, ", ")))
+		}
+		if len(summary.BypassableRulesets) > 0 {
+			lines = append(lines, fmt.Sprintf("%s%s: %s", icon("⚠️  "), msg("Rulesets With Bypass Actors"), strings.Join(summary.BypassableRulesets, ", ")))
+		}
+		if len(summary.OrphanedChecks) > 0 {
+			lines = append(lines, fmt.Sprintf("%s%s: %s", icon("⚠️  "), msg("Potentially Orphaned Checks"), strings.Join(summary.OrphanedChecks, ", ")))
+		}
+		for i, line := range lines {
+			prefix := "├─"
+			if i == len(lines)-1 {
+				prefix = "└─"
+			}
+			fmt.Fprintf(w, "%s %s\n", prefix, line)
+		}
+		fmt.Fprintln(w)
+	}
 
 	// Repository Settings
 	if shouldIncludeSectionOutput("settings", sectionsFilter) {
-		fmt.Printf("⚙️  Repository Settings\n")
-		fmt.Printf("├─ Private: %s\n", boolToIcon(governance.RepoSettings.Private))
-		fmt.Printf("├─ Archived: %s\n", boolToIcon(governance.RepoSettings.Archived))
-		fmt.Printf("├─ Default Branch: %s\n", governance.RepoSettings.DefaultBranch)
-		fmt.Printf("├─ Issues: %s\n", boolToIcon(governance.RepoSettings.HasIssues))
-		fmt.Printf("├─ Projects: %s\n", boolToIcon(governance.RepoSettings.HasProjects))
-		fmt.Printf("├─ Wiki: %s\n", boolToIcon(governance.RepoSettings.HasWiki))
-		fmt.Printf("├─ Allow Merge Commit: %s\n", boolToIcon(governance.RepoSettings.AllowMergeCommit))
-		fmt.Printf("├─ Allow Squash Merge: %s\n", boolToIcon(governance.RepoSettings.AllowSquashMerge))
-		fmt.Printf("├─ Allow Rebase Merge: %s\n", boolToIcon(governance.RepoSettings.AllowRebaseMerge))
-		fmt.Printf("└─ Delete Branch on Merge: %s\n\n", boolToIcon(governance.RepoSettings.DeleteBranchOnMerge))
+		fmt.Fprintf(w, "%s%s\n", icon("⚙️  "), msg("Repository Settings"))
+		fmt.Fprintf(w, "├─ %s: %s\n", msg("Visibility"), repoVisibility(governance.RepoSettings))
+		fmt.Fprintf(w, "├─ %s: %s\n", msg("Archived"), boolToIcon(governance.RepoSettings.Archived))
+		if governance.RepoSettings.Empty {
+			fmt.Fprintf(w, "├─ %s%s\n", icon("📭 "), msg("No commits yet"))
+		}
+		if governance.RepoSettings.Fork {
+			fmt.Fprintf(w, "├─ %s%s %s\n", icon("🔱 "), msg("Fork of"), forkText(governance.RepoSettings))
+		}
+		fmt.Fprintf(w, "├─ %s: %s\n", msg("Default Branch"), governance.RepoSettings.DefaultBranch)
+		if governance.RepoSettings.CreatedAt != "" {
+			fmt.Fprintf(w, "├─ %s: %s\n", msg("Created"), governance.RepoSettings.CreatedAt)
+		}
+		if governance.RepoSettings.PushedAt != "" {
+			fmt.Fprintf(w, "├─ %s: %s\n", msg("Last Push"), governance.RepoSettings.PushedAt)
+		}
+		fmt.Fprintf(w, "├─ %s: %s\n", msg("Issues"), boolToIcon(governance.RepoSettings.HasIssues))
+		fmt.Fprintf(w, "├─ %s: %s\n", msg("Projects"), boolToIcon(governance.RepoSettings.HasProjects))
+		fmt.Fprintf(w, "├─ %s: %s\n", msg("Wiki"), boolToIcon(governance.RepoSettings.HasWiki))
+		fmt.Fprintf(w, "├─ %s: %s\n", msg("Allow Merge Commit"), boolToIcon(governance.RepoSettings.AllowMergeCommit))
+		fmt.Fprintf(w, "├─ %s: %s\n", msg("Allow Squash Merge"), boolToIcon(governance.RepoSettings.AllowSquashMerge))
+		fmt.Fprintf(w, "├─ %s: %s\n", msg("Allow Rebase Merge"), boolToIcon(governance.RepoSettings.AllowRebaseMerge))
+		fmt.Fprintf(w, "├─ %s: %s\n", msg("Delete Branch on Merge"), boolToIcon(governance.RepoSettings.DeleteBranchOnMerge))
+		for _, format := range mergeCommitFormats(governance.RepoSettings) {
+			fmt.Fprintf(w, "├─ %s: %s\n", msg(format.label), format.value)
+		}
+		topics := msg("None")
+		if len(governance.RepoSettings.Topics) > 0 {
+			topics = strings.Join(governance.RepoSettings.Topics, ", ")
+		}
+		if len(governance.RepoSettings.CustomProperties) == 0 {
+			fmt.Fprintf(w, "└─ %s: %s\n\n", msg("Topics"), topics)
+		} else {
+			fmt.Fprintf(w, "├─ %s: %s\n", msg("Topics"), topics)
+			fmt.Fprintf(w, "└─ %s:\n", msg("Custom Properties"))
+			for i, property := range governance.RepoSettings.CustomProperties {
+				propertyPrefix := "├─"
+				if i == len(governance.RepoSettings.CustomProperties)-1 {
+					propertyPrefix = "└─"
+				}
+				fmt.Fprintf(w, "   %s %s: %s\n", propertyPrefix, property.Key, property.Value)
+			}
+			fmt.Fprintln(w)
+		}
+	}
+
+	// Metrics
+	if shouldIncludeSectionOutput("settings", sectionsFilter) {
+		settings := governance.RepoSettings
+		fmt.Fprintf(w, "%s%s\n", icon("📈 "), msg("Metrics"))
+		if settings.Language != "" {
+			fmt.Fprintf(w, "├─ %s: %s\n", msg("Language"), settings.Language)
+		}
+		fmt.Fprintf(w, "├─ %s: %d KB\n", msg("Size"), settings.SizeKB)
+		fmt.Fprintf(w, "├─ %s: %d\n", msg("Open Issues"), settings.OpenIssues)
+		fmt.Fprintf(w, "├─ %s: %d\n", msg("Forks"), settings.Forks)
+		fmt.Fprintf(w, "├─ %s: %d\n", msg("Stars"), settings.Stargazers)
+		fmt.Fprintf(w, "└─ %s: %d\n\n", msg("Watchers"), settings.Watchers)
 	}
 
 	// Security Settings
 	if shouldIncludeSectionOutput("security", sectionsFilter) {
-		fmt.Printf("🔒 Security Settings\n")
-		fmt.Printf("├─ Vulnerability Alerts: %s\n", boolToIcon(governance.SecuritySettings.VulnerabilityAlerts))
-		fmt.Printf("├─ Automated Security Fixes: %s\n", boolToIcon(governance.SecuritySettings.AutomatedSecurityFixes))
-		fmt.Printf("├─ Secret Scanning: %s\n", boolToIcon(governance.SecuritySettings.SecretScanning))
-		fmt.Printf("├─ Secret Scanning Push Protection: %s\n", boolToIcon(governance.SecuritySettings.SecretScanningPushProtection))
-		fmt.Printf("└─ Dependency Graph: %s\n\n", boolToIcon(governance.SecuritySettings.DependencyGraphEnabled))
+		fmt.Fprintf(w, "%s%s\n", icon("🔒 "), msg("Security Settings"))
+		fmt.Fprintf(w, "├─ %s: %s\n", msg("Vulnerability Alerts"), securityValue(governance.SecuritySettings, "vulnerability_alerts", governance.SecuritySettings.VulnerabilityAlerts))
+		fmt.Fprintf(w, "├─ %s: %s\n", msg("Automated Security Fixes"), securityValue(governance.SecuritySettings, "automated_security_fixes", governance.SecuritySettings.AutomatedSecurityFixes))
+		fmt.Fprintf(w, "├─ %s: %s\n", msg("Secret Scanning"), securityValue(governance.SecuritySettings, "secret_scanning", governance.SecuritySettings.SecretScanning))
+		fmt.Fprintf(w, "├─ %s: %s\n", msg("Secret Scanning Push Protection"), securityValue(governance.SecuritySettings, "secret_scanning_push_protection", governance.SecuritySettings.SecretScanningPushProtection))
+		security := governance.SecuritySettings
+		if security.SecretScanningAlertCount != nil {
+			fmt.Fprintf(w, "├─ %s: %s\n", msg("Dependency Graph"), securityValue(security, "dependency_graph_enabled", security.DependencyGraphEnabled))
+			fmt.Fprintf(w, "├─ %s: %s\n", msg("Open Secret Scanning Alerts"), alertCount(security.SecretScanningAlertCount))
+			fmt.Fprintf(w, "└─ %s: %s\n\n", msg("Open Dependabot Alerts"), alertCount(security.DependabotAlertCount))
+		} else {
+			fmt.Fprintf(w, "└─ %s: %s\n\n", msg("Dependency Graph"), securityValue(security, "dependency_graph_enabled", security.DependencyGraphEnabled))
+		}
 	}
 
 	// Repository Rulesets
 	if len(governance.Rulesets) > 0 && shouldIncludeSectionOutput("rulesets", sectionsFilter) {
-		fmt.Printf("📜 Repository Rulesets\n")
+		fmt.Fprintf(w, "%s%s\n", icon("📜 "), msg("Repository Rulesets"))
 		for i, ruleset := range governance.Rulesets {
 			prefix := "├─"
 			if i == len(governance.Rulesets)-1 {
 				prefix = "└─"
 			}
-			fmt.Printf("%s %s (Pattern: %s)\n", prefix, ruleset.Name, ruleset.Pattern)
+			enforcement := ""
+			if ruleset.Enforcement != "" {
+				enforcement = ", " + msg("Enforcement") + ": " + ruleset.Enforcement
+			}
+			if ruleset.Source == "organization" {
+				enforcement += ", " + msg("Source") + ": organization"
+			}
+			target := truncateToWidth(refTargetText(ruleset), prefix, " ", ruleset.Name, " (", msg("Applies to"), ": ", enforcement, ")")
+			fmt.Fprintf(w, "%s %s (%s: %s%s)\n", prefix, ruleset.Name, msg("Applies to"), target, enforcement)
+
+			// Timestamps help spot recent changes and are shown with -v
+			if verbosity >= verboseProgress && ruleset.UpdatedAt != "" {
+				fmt.Fprintf(w, "   ├─ %s: %s, %s: %s\n", msg("Created"), ruleset.CreatedAt, msg("Updated"), ruleset.UpdatedAt)
+			}
 
 			// Show main settings
-			fmt.Printf("   ├─ Enforce Admins: %s\n", boolToIcon(ruleset.EnforceAdmins))
-			fmt.Printf("   ├─ Require PR Reviews: %s\n", boolToIcon(ruleset.RequiredPullRequestReviews))
+			fmt.Fprintf(w, "   ├─ %s: %s\n", msg("Enforce Admins"), boolToIcon(ruleset.EnforceAdmins))
+			fmt.Fprintf(w, "   ├─ %s: %s\n", msg("Require PR Reviews"), boolToIcon(ruleset.RequiredPullRequestReviews))
 			if ruleset.RequiredPullRequestReviews {
-				fmt.Printf("   │  ├─ Required Approving Reviews: %d\n", ruleset.RequiredApprovingReviewCount)
-				fmt.Printf("   │  ├─ Dismiss Stale Reviews: %s\n", boolToIcon(ruleset.DismissStaleReviews))
-				fmt.Printf("   │  └─ Require Code Owner Reviews: %s\n", boolToIcon(ruleset.RequireCodeOwnerReviews))
+				fmt.Fprintf(w, "   │  ├─ %s: %d\n", msg("Required Approving Reviews"), ruleset.RequiredApprovingReviewCount)
+				fmt.Fprintf(w, "   │  ├─ %s: %s\n", msg("Dismiss Stale Reviews"), boolToIcon(ruleset.DismissStaleReviews))
+				fmt.Fprintf(w, "   │  └─ %s: %s\n", msg("Require Code Owner Reviews"), boolToIcon(ruleset.RequireCodeOwnerReviews))
 			}
 
 			// Show branch protection settings
-			fmt.Printf("   ├─ Required Linear History: %s\n", boolToIcon(ruleset.RequiredLinearHistory))
-			fmt.Printf("   ├─ Allow Force Pushes: %s\n", boolToIcon(ruleset.AllowForcePushes))
-			fmt.Printf("   ├─ Allow Deletions: %s\n", boolToIcon(ruleset.AllowDeletions))
-			fmt.Printf("   ├─ Require Conversation Resolution: %s\n", boolToIcon(ruleset.RequiredConversationResolution))
+			fmt.Fprintf(w, "   ├─ %s: %s\n", msg("Required Linear History"), boolToIcon(ruleset.RequiredLinearHistory))
+			fmt.Fprintf(w, "   ├─ %s: %s\n", msg("Require Signed Commits"), boolToIcon(ruleset.RequireSignedCommits))
+			fmt.Fprintf(w, "   ├─ %s: %s\n", msg("Allow Force Pushes"), boolToIcon(ruleset.AllowForcePushes))
+			fmt.Fprintf(w, "   ├─ %s: %s\n", msg("Allow Deletions"), boolToIcon(ruleset.AllowDeletions))
+			fmt.Fprintf(w, "   ├─ %s: %s\n", msg("Require Conversation Resolution"), boolToIcon(ruleset.RequiredConversationResolution))
+			fmt.Fprintf(w, "   ├─ %s: %s\n", msg("Merge Queue"), mergeQueueText(ruleset.MergeQueue))
+			if len(ruleset.BypassActors) > 0 {
+				fmt.Fprintf(w, "   ├─ %s:\n", msg("Bypass Actors"))
+				for j, actor := range ruleset.BypassActors {
+					actorPrefix := "├─"
+					if j == len(ruleset.BypassActors)-1 {
+						actorPrefix = "└─"
+					}
+					fmt.Fprintf(w, "   │  %s %s\n", actorPrefix, actor)
+				}
+			}
 
 			// Show required status checks
 			if len(ruleset.RequiredStatusChecks) > 0 {
-				fmt.Printf("   └─ Required Status Checks:\n")
+				fmt.Fprintf(w, "   └─ %s:\n", msg("Required Status Checks"))
 				for j, check := range ruleset.RequiredStatusChecks {
 					checkPrefix := "├─"
 					if j == len(ruleset.RequiredStatusChecks)-1 {
 						checkPrefix = "└─"
 					}
-					fmt.Printf("      %s %s\n", checkPrefix, check)
+					orphaned := ""
+					if slices.Contains(ruleset.OrphanedChecks, check) {
+						orphaned = " " + icon("⚠️  ") + msg("potentially orphaned")
+					}
+					fmt.Fprintf(w, "      %s %s%s\n", checkPrefix, check, orphaned)
 				}
 			} else {
-				fmt.Printf("   └─ Required Status Checks: None\n")
+				fmt.Fprintf(w, "   └─ %s: %s\n", msg("Required Status Checks"), msg("None"))
 			}
 
 			// Add spacing between rulesets except for the last one
 			if i < len(governance.Rulesets)-1 {
-				fmt.Printf("   \n")
+				fmt.Fprintf(w, "   \n")
 			}
 		}
-		fmt.Println()
+		fmt.Fprintln(w)
+	} else if shouldIncludeSectionOutput("rulesets", sectionsFilter) {
+		emptySection(w, icon("📜 ")+msg("Repository Rulesets"))
 	}
 
 	// Collaborators
 	if len(governance.Collaborators) > 0 && shouldIncludeSectionOutput("collaborators", sectionsFilter) {
-		fmt.Printf("👥 Collaborators (%d)\n", len(governance.Collaborators))
+		fmt.Fprintf(w, "%s%s (%d)\n", icon("👥 "), msg("Collaborators"), len(governance.Collaborators))
 		for i, collab := range governance.Collaborators {
 			prefix := "├─"
 			if i == len(governance.Collaborators)-1 {
 				prefix = "└─"
 			}
-			fmt.Printf("%s %s (%s) - %s\n", prefix, collab.Login, collab.Type, permissionToIcon(collab.Permission))
+			source := ""
+			if collab.Source != "" {
+				source = fmt.Sprintf(" [%s via %s]", collab.RoleName, collab.Source)
+			}
+			if withActivity {
+				lastCommit := collab.LastCommitDate
+				if lastCommit == "" {
+					lastCommit = msg("None")
+				}
+				source += fmt.Sprintf(", %s %s", msg("last commit"), lastCommit)
+			}
+			if collab.Inactive {
+				source += " [" + msg("inactive") + "]"
+			}
+			fmt.Fprintf(w, "%s %s (%s) - %s%s\n", prefix, collab.Login, collab.Type, permissionToIcon(collab.Permission), source)
 		}
-		fmt.Println()
+		fmt.Fprintln(w)
+	} else if shouldIncludeSectionOutput("collaborators", sectionsFilter) {
+		emptySection(w, icon("👥 ")+msg("Collaborators"))
 	}
 
 	// Teams
 	if len(governance.Teams) > 0 && shouldIncludeSectionOutput("teams", sectionsFilter) {
-		fmt.Printf("Teams (%d)\n", len(governance.Teams))
+		fmt.Fprintf(w, "%s (%d)\n", msg("Teams"), len(governance.Teams))
 		for i, team := range governance.Teams {
 			prefix := "├─"
 			if i == len(governance.Teams)-1 {
 				prefix = "└─"
 			}
-			fmt.Printf("%s %s (@%s) - %s\n", prefix, team.Name, team.Slug, permissionToIcon(team.Permission))
+			fmt.Fprintf(w, "%s %s (@%s) - %s\n", prefix, team.Name, team.Slug, permissionToIcon(team.Permission))
 		}
-		fmt.Println()
+		fmt.Fprintln(w)
+	} else if shouldIncludeSectionOutput("teams", sectionsFilter) {
+		emptySection(w, msg("Teams"))
 	}
 
 	// Labels
 	if len(governance.IssueLabels) > 0 && shouldIncludeSectionOutput("labels", sectionsFilter) {
-		fmt.Printf("🏷️  Labels (%d)\n", len(governance.IssueLabels))
+		fmt.Fprintf(w, "%s%s (%d)\n", icon("🏷️  "), msg("Labels"), len(governance.IssueLabels))
 		for i, label := range governance.IssueLabels {
 			prefix := "├─"
 			if i == len(governance.IssueLabels)-1 {
@@ -150,45 +1480,523 @@ func outputTable(governance *GovernanceConfig, sectionsFilter []string) error {
 			}
 			description := ""
 			if label.Description != "" {
-				description = fmt.Sprintf(" (%s)", label.Description)
+				description = fmt.Sprintf(" (%s)", truncateToWidth(label.Description, prefix, " ", label.Name, " #", label.Color, " ()"))
 			}
-			fmt.Printf("%s %s #%s%s\n", prefix, label.Name, label.Color, description)
+			fmt.Fprintf(w, "%s %s #%s%s\n", prefix, label.Name, label.Color, description)
 		}
-		fmt.Println()
+		fmt.Fprintln(w)
+	} else if shouldIncludeSectionOutput("labels", sectionsFilter) {
+		emptySection(w, icon("🏷️  ")+msg("Labels"))
 	}
 
 	// Milestones
 	if len(governance.Milestones) > 0 && shouldIncludeSectionOutput("milestones", sectionsFilter) {
-		fmt.Printf("🎯 Milestones (%d)\n", len(governance.Milestones))
+		fmt.Fprintf(w, "%s%s (%d)\n", icon("🎯 "), msg("Milestones"), len(governance.Milestones))
 		for i, milestone := range governance.Milestones {
 			prefix := "├─"
 			if i == len(governance.Milestones)-1 {
 				prefix = "└─"
 			}
-			state := "🟢"
+			state := icon("🟢")
 			if milestone.State == "closed" {
-				state = "🔴"
+				state = icon("🔴")
+			}
+			if state == "" {
+				state = "[" + milestone.State + "]"
 			}
 			dueDate := ""
 			if milestone.DueOn != "" {
-				dueDate = fmt.Sprintf(" (Due: %s)", milestone.DueOn)
+				dueDate = fmt.Sprintf(" (%s: %s)", msg("Due"), milestone.DueOn)
 			}
-			fmt.Printf("%s %s %s%s\n", prefix, state, milestone.Title, dueDate)
+			fmt.Fprintf(w, "%s %s %s%s\n", prefix, state, milestone.Title, dueDate)
 			if milestone.Description != "" {
-				fmt.Printf("   %s\n", milestone.Description)
+				fmt.Fprintf(w, "   %s\n", milestone.Description)
+			}
+		}
+		fmt.Fprintln(w)
+	} else if shouldIncludeSectionOutput("milestones", sectionsFilter) {
+		emptySection(w, icon("🎯 ")+msg("Milestones"))
+	}
+
+	// Webhooks
+	if len(governance.Webhooks) > 0 && shouldIncludeSectionOutput("webhooks", sectionsFilter) {
+		fmt.Fprintf(w, "%s%s (%d)\n", icon("🪝 "), msg("Webhooks"), len(governance.Webhooks))
+		for i, hook := range governance.Webhooks {
+			prefix := "├─"
+			if i == len(governance.Webhooks)-1 {
+				prefix = "└─"
+			}
+			fmt.Fprintf(w, "%s %s (%s)\n", prefix, truncateToWidth(hook.URL, prefix, " ", " (", hook.ContentType, ")"), hook.ContentType)
+			fmt.Fprintf(w, "   ├─ %s: %s\n", msg("Active"), boolToIcon(hook.Active))
+			fmt.Fprintf(w, "   ├─ %s: %s\n", msg("Secret Configured"), boolToIcon(hook.Secret))
+			fmt.Fprintf(w, "   └─ %s: %s\n", msg("Events"), strings.Join(hook.Events, ", "))
+		}
+		fmt.Fprintln(w)
+	} else if shouldIncludeSectionOutput("webhooks", sectionsFilter) {
+		emptySection(w, icon("🪝 ")+msg("Webhooks"))
+	}
+
+	// Environments
+	if len(governance.Environments) > 0 && shouldIncludeSectionOutput("environments", sectionsFilter) {
+		fmt.Fprintf(w, "%s%s (%d)\n", icon("🚀 "), msg("Environments"), len(governance.Environments))
+		for i, env := range governance.Environments {
+			prefix := "├─"
+			if i == len(governance.Environments)-1 {
+				prefix = "└─"
+			}
+			reviewers := msg("None")
+			if len(env.Reviewers) > 0 {
+				reviewers = strings.Join(env.Reviewers, ", ")
+			}
+			fmt.Fprintf(w, "%s %s\n", prefix, env.Name)
+			fmt.Fprintf(w, "   ├─ %s: %d min\n", msg("Wait Timer"), env.WaitTimer)
+			fmt.Fprintf(w, "   ├─ %s: %s\n", msg("Required Reviewers"), reviewers)
+			fmt.Fprintf(w, "   └─ %s: %s\n", msg("Deployment Branches"), env.DeploymentBranchPolicy)
+		}
+		fmt.Fprintln(w)
+	} else if shouldIncludeSectionOutput("environments", sectionsFilter) {
+		emptySection(w, icon("🚀 ")+msg("Environments"))
+	}
+
+	// Branch Protection
+	if len(governance.ProtectedBranches) > 0 && shouldIncludeSectionOutput("branch-protection", sectionsFilter) {
+		fmt.Fprintf(w, "%s%s (%d)\n", icon("🛡️  "), msg("Branch Protection"), len(governance.ProtectedBranches))
+		for i, branch := range governance.ProtectedBranches {
+			prefix := "├─"
+			if i == len(governance.ProtectedBranches)-1 {
+				prefix = "└─"
+			}
+			restrictions := msg("None")
+			if len(branch.Restrictions) > 0 {
+				restrictions = strings.Join(branch.Restrictions, ", ")
+			}
+			fmt.Fprintf(w, "%s %s: %s\n", prefix, msg("Branch"), branch.Name)
+			fmt.Fprintf(w, "   ├─ %s: %d\n", msg("Required Reviews"), branch.RequiredReviews)
+			fmt.Fprintf(w, "   ├─ %s: %s\n", msg("Enforce Admins"), boolToIcon(branch.EnforceAdmins))
+			fmt.Fprintf(w, "   ├─ %s: %s\n", msg("Require Signed Commits"), boolToIcon(branch.RequireSignedCommits))
+			fmt.Fprintf(w, "   └─ %s: %s\n", msg("Push Restrictions"), restrictions)
+		}
+		fmt.Fprintln(w)
+	} else if shouldIncludeSectionOutput("branch-protection", sectionsFilter) {
+		emptySection(w, icon("🛡️  ")+msg("Branch Protection"))
+	}
+
+	// Deploy Keys
+	if len(governance.DeployKeys) > 0 && shouldIncludeSectionOutput("deploy-keys", sectionsFilter) {
+		fmt.Fprintf(w, "%s%s (%d)\n", icon("🔑 "), msg("Deploy Keys"), len(governance.DeployKeys))
+		for i, key := range governance.DeployKeys {
+			prefix := "├─"
+			if i == len(governance.DeployKeys)-1 {
+				prefix = "└─"
+			}
+			lastUsed := msg("Never")
+			if key.LastUsed != "" {
+				lastUsed = key.LastUsed
+			}
+			fmt.Fprintf(w, "%s %s\n", prefix, key.Title)
+			fmt.Fprintf(w, "   ├─ %s: %s\n", msg("Read Only"), boolToIcon(key.ReadOnly))
+			fmt.Fprintf(w, "   ├─ %s: %s\n", msg("Created"), key.CreatedAt)
+			fmt.Fprintf(w, "   └─ %s: %s\n", msg("Last Used"), lastUsed)
+		}
+		fmt.Fprintln(w)
+	} else if shouldIncludeSectionOutput("deploy-keys", sectionsFilter) {
+		emptySection(w, icon("🔑 ")+msg("Deploy Keys"))
+	}
+
+	// Actions
+	if governance.Actions != nil && shouldIncludeSectionOutput("actions", sectionsFilter) {
+		fmt.Fprintf(w, "%s%s\n", icon("⚡ "), msg("Actions"))
+		fmt.Fprintf(w, "├─ %s (%d)\n", msg("Secrets"), len(governance.Actions.SecretNames))
+		for i, name := range governance.Actions.SecretNames {
+			prefix := "├─"
+			if i == len(governance.Actions.SecretNames)-1 {
+				prefix = "└─"
+			}
+			fmt.Fprintf(w, "│  %s %s\n", prefix, name)
+		}
+		fmt.Fprintf(w, "└─ %s (%d)\n", msg("Variables"), len(governance.Actions.Variables))
+		for i, variable := range governance.Actions.Variables {
+			prefix := "├─"
+			if i == len(governance.Actions.Variables)-1 {
+				prefix = "└─"
+			}
+			fmt.Fprintf(w, "   %s %s = %s\n", prefix, variable.Key, variable.Value)
+		}
+		fmt.Fprintln(w)
+	} else if shouldIncludeSectionOutput("actions", sectionsFilter) {
+		emptySection(w, icon("⚡ ")+msg("Actions"))
+	}
+
+	// CODEOWNERS
+	if governance.CodeOwners != nil && shouldIncludeSectionOutput("codeowners", sectionsFilter) {
+		codeOwners := governance.CodeOwners
+		fmt.Fprintf(w, "%s%s\n", icon("📋 "), msg("CODEOWNERS"))
+		if !codeOwners.Exists {
+			fmt.Fprintf(w, "└─ %s: %s\n", msg("Exists"), boolToIcon(false))
+		} else {
+			fmt.Fprintf(w, "├─ %s: %s\n", msg("Exists"), boolToIcon(true))
+			fmt.Fprintf(w, "├─ %s: %s\n", msg("Path"), codeOwners.Path)
+			fmt.Fprintf(w, "├─ %s: %d\n", msg("Rules"), codeOwners.RuleCount)
+			for i, entry := range codeOwners.Entries {
+				entryPrefix := "├─"
+				if i == len(codeOwners.Entries)-1 {
+					entryPrefix = "└─"
+				}
+				fmt.Fprintf(w, "│  %s %s → %s\n", entryPrefix, entry.Pattern, strings.Join(entry.Owners, ", "))
+			}
+			if len(codeOwners.Errors) == 0 {
+				fmt.Fprintf(w, "└─ %s: %s\n", msg("Errors"), msg("None"))
+			} else {
+				fmt.Fprintf(w, "└─ %s:\n", msg("Errors"))
+				for i, codeOwnersErr := range codeOwners.Errors {
+					errPrefix := "├─"
+					if i == len(codeOwners.Errors)-1 {
+						errPrefix = "└─"
+					}
+					fmt.Fprintf(w, "   %s %s\n", errPrefix, codeOwnersErr)
+				}
+			}
+		}
+		fmt.Fprintln(w)
+	} else if shouldIncludeSectionOutput("codeowners", sectionsFilter) {
+		emptySection(w, icon("📋 ")+msg("CODEOWNERS"))
+	}
+
+	// Pages
+	if governance.Pages != nil && shouldIncludeSectionOutput("pages", sectionsFilter) {
+		pages := governance.Pages
+		fmt.Fprintf(w, "%s%s\n", icon("🌐 "), msg("GitHub Pages"))
+		if !pages.Enabled {
+			fmt.Fprintf(w, "└─ %s: %s\n", msg("Enabled"), boolToIcon(false))
+		} else {
+			fmt.Fprintf(w, "├─ %s: %s\n", msg("Enabled"), boolToIcon(true))
+			fmt.Fprintf(w, "├─ %s: %s %s\n", msg("Source"), pages.SourceBranch, pages.SourcePath)
+			if pages.CustomDomain != "" {
+				fmt.Fprintf(w, "├─ %s: %s\n", msg("Custom Domain"), pages.CustomDomain)
+			}
+			fmt.Fprintf(w, "├─ %s: %s\n", msg("HTTPS Enforced"), boolToIcon(pages.HTTPSEnforced))
+			fmt.Fprintf(w, "└─ %s: %s\n", msg("Public"), boolToIcon(pages.Public))
+		}
+		fmt.Fprintln(w)
+	} else if shouldIncludeSectionOutput("pages", sectionsFilter) {
+		emptySection(w, icon("🌐 ")+msg("GitHub Pages"))
+	}
+
+	// Autolinks
+	if len(governance.Autolinks) > 0 && shouldIncludeSectionOutput("autolinks", sectionsFilter) {
+		fmt.Fprintf(w, "%s%s (%d)\n", icon("🔗 "), msg("Autolinks"), len(governance.Autolinks))
+		for i, autolink := range governance.Autolinks {
+			prefix := "├─"
+			if i == len(governance.Autolinks)-1 {
+				prefix = "└─"
+			}
+			fmt.Fprintf(w, "%s %s → %s (%s: %s)\n", prefix, autolink.KeyPrefix, autolink.URLTemplate, msg("alphanumeric"), boolToIcon(autolink.IsAlphanumeric))
+		}
+		fmt.Fprintln(w)
+	} else if shouldIncludeSectionOutput("autolinks", sectionsFilter) {
+		emptySection(w, icon("🔗 ")+msg("Autolinks"))
+	}
+
+	// Workflows
+	if governance.Workflows != nil && shouldIncludeSectionOutput("workflows", sectionsFilter) {
+		workflows := governance.Workflows
+		fmt.Fprintf(w, "%s%s (%d)\n", icon("⚡ "), msg("Workflows"), len(workflows.Workflows))
+		if len(workflows.Workflows) == 0 || (workflows.ActionsEnabled != nil && !*workflows.ActionsEnabled) {
+			fmt.Fprintf(w, "└─ %s: %s\n", msg("Actions Enabled"), actionsEnabledText(workflows.ActionsEnabled))
+		} else {
+			fmt.Fprintf(w, "├─ %s: %s\n", msg("Actions Enabled"), actionsEnabledText(workflows.ActionsEnabled))
+			for i, workflow := range workflows.Workflows {
+				prefix := "├─"
+				if i == len(workflows.Workflows)-1 {
+					prefix = "└─"
+				}
+				fmt.Fprintf(w, "%s %s (%s) - %s\n", prefix, workflow.Name, workflow.Path, workflow.State)
+			}
+		}
+		fmt.Fprintln(w)
+	} else if shouldIncludeSectionOutput("workflows", sectionsFilter) {
+		emptySection(w, icon("⚡ ")+msg("Workflows"))
+	}
+
+	// Dependabot
+	if governance.Dependabot != nil && shouldIncludeSectionOutput("dependabot", sectionsFilter) {
+		dependabot := governance.Dependabot
+		fmt.Fprintf(w, "%s%s\n", icon("🤖 "), msg("Dependabot"))
+		if !dependabot.Exists {
+			fmt.Fprintf(w, "└─ %s: %s\n", msg("Exists"), boolToIcon(false))
+		} else {
+			fmt.Fprintf(w, "├─ %s: %s\n", msg("Exists"), boolToIcon(true))
+			if len(dependabot.Ecosystems) == 0 {
+				fmt.Fprintf(w, "└─ %s: %s\n", msg("Path"), dependabot.Path)
+			} else {
+				fmt.Fprintf(w, "├─ %s: %s\n", msg("Path"), dependabot.Path)
+				fmt.Fprintf(w, "└─ %s (%d)\n", msg("Updates"), len(dependabot.Ecosystems))
+				for i, ecosystem := range dependabot.Ecosystems {
+					prefix := "├─"
+					if i == len(dependabot.Ecosystems)-1 {
+						prefix = "└─"
+					}
+					fmt.Fprintf(w, "   %s %s (%s)\n", prefix, ecosystem, dependabot.Schedules[i])
+				}
+			}
+		}
+		fmt.Fprintln(w)
+	} else if shouldIncludeSectionOutput("dependabot", sectionsFilter) {
+		emptySection(w, icon("🤖 ")+msg("Dependabot"))
+	}
+
+	// Releases and tag protection
+	if shouldIncludeSectionOutput("releases", sectionsFilter) && (len(governance.Releases) > 0 || len(governance.TagProtections) > 0) {
+		fmt.Fprintf(w, "%s%s (%d)\n", icon("🚀 "), msg("Releases"), len(governance.Releases))
+		for _, release := range governance.Releases {
+			flags := ""
+			if release.Draft {
+				flags += " [draft]"
+			}
+			if release.Prerelease {
+				flags += " [prerelease]"
+			}
+			fmt.Fprintf(w, "├─ %s %s%s %s\n", release.TagName, release.Name, flags, release.PublishedAt)
+		}
+		if len(governance.TagProtections) == 0 {
+			fmt.Fprintf(w, "└─ %s: %s\n", msg("Protected Tags"), msg("None"))
+		} else {
+			fmt.Fprintf(w, "└─ %s:\n", msg("Protected Tags"))
+			for i, protection := range governance.TagProtections {
+				prefix := "├─"
+				if i == len(governance.TagProtections)-1 {
+					prefix = "└─"
+				}
+				fmt.Fprintf(w, "   %s %s\n", prefix, protection.Pattern)
+			}
+		}
+		fmt.Fprintln(w)
+	} else if shouldIncludeSectionOutput("releases", sectionsFilter) {
+		emptySection(w, icon("🚀 ")+msg("Releases"))
+	}
+
+	// Templates
+	if governance.Templates != nil && shouldIncludeSectionOutput("templates", sectionsFilter) {
+		templates := governance.Templates
+		fmt.Fprintf(w, "%s%s\n", icon("📝 "), msg("Issue & PR Templates"))
+		fmt.Fprintf(w, "├─ %s: %s\n", msg("PR Template"), boolToIcon(templates.HasPRTemplate))
+		fmt.Fprintf(w, "├─ %s: %s\n", msg("Template Chooser Config"), boolToIcon(templates.HasConfigYML))
+		fmt.Fprintf(w, "└─ %s (%d)\n", msg("Issue Templates"), len(templates.IssueTemplates))
+		for i, path := range templates.IssueTemplates {
+			prefix := "├─"
+			if i == len(templates.IssueTemplates)-1 {
+				prefix = "└─"
+			}
+			fmt.Fprintf(w, "   %s %s\n", prefix, path)
+		}
+		fmt.Fprintln(w)
+	} else if shouldIncludeSectionOutput("templates", sectionsFilter) {
+		emptySection(w, icon("📝 ")+msg("Issue & PR Templates"))
+	}
+
+	// Branches
+	if len(governance.Branches) > 0 && shouldIncludeSectionOutput("branches", sectionsFilter) {
+		fmt.Fprintf(w, "%s%s (%d)\n", icon("🌿 "), msg("Branches"), len(governance.Branches))
+		for i, branch := range governance.Branches {
+			prefix := "├─"
+			if i == len(governance.Branches)-1 {
+				prefix = "└─"
+			}
+			flags := ""
+			if branch.Protected {
+				flags += " [protected]"
+			}
+			if branch.Stale {
+				flags += " [stale]"
+			}
+			fmt.Fprintf(w, "%s %s%s: %d %s, %d %s, %s %s\n", prefix, branch.Name, flags, branch.AheadBy, msg("ahead"), branch.BehindBy, msg("behind"), msg("last commit"), branch.LastCommitDate)
+		}
+		fmt.Fprintln(w)
+	} else if shouldIncludeSectionOutput("branches", sectionsFilter) {
+		emptySection(w, icon("🌿 ")+msg("Branches"))
+	}
+
+	// Tag rulesets
+	if len(governance.TagRules) > 0 && shouldIncludeSectionOutput("tag-rules", sectionsFilter) {
+		fmt.Fprintf(w, "%s%s (%d)\n", icon("🔖 "), msg("Tag Rulesets"), len(governance.TagRules))
+		for i, rule := range governance.TagRules {
+			prefix := "├─"
+			if i == len(governance.TagRules)-1 {
+				prefix = "└─"
+			}
+			fmt.Fprintf(w, "%s %s (%s: %s) - %s\n", prefix, rule.Name, msg("Pattern"), truncateToWidth(rule.Pattern, prefix, rule.Name, " (", msg("Pattern"), ": ) - ", rule.Enforcement), rule.Enforcement)
+		}
+		fmt.Fprintln(w)
+	} else if shouldIncludeSectionOutput("tag-rules", sectionsFilter) {
+		emptySection(w, icon("🔖 ")+msg("Tag Rulesets"))
+	}
+
+	// Community files
+	if governance.CommunityFiles != nil && shouldIncludeSectionOutput("community-files", sectionsFilter) {
+		community := governance.CommunityFiles
+		fmt.Fprintf(w, "%s%s (%d%% %s)\n", icon("🤝 "), msg("Community Files"), community.HealthPercentage, msg("health"))
+		fmt.Fprintf(w, "├─ %s: %s\n", msg("License"), communityLicense(community))
+		fmt.Fprintf(w, "├─ %s: %s\n", msg("Contributing"), boolToIcon(community.HasContributing))
+		fmt.Fprintf(w, "├─ %s: %s\n", msg("Code of Conduct"), boolToIcon(community.HasCodeOfConduct))
+		fmt.Fprintf(w, "├─ %s: %s\n", msg("Security Policy"), boolToIcon(community.HasSecurityPolicy))
+		fmt.Fprintf(w, "└─ %s: %s\n", msg("README"), boolToIcon(community.HasReadme))
+		fmt.Fprintln(w)
+	} else if shouldIncludeSectionOutput("community-files", sectionsFilter) {
+		emptySection(w, icon("🤝 ")+msg("Community Files"))
+	}
+
+	// Recent governance events, only fetched with --with-audit
+	if len(governance.RecentEvents) > 0 && shouldIncludeSectionOutput("recent-events", sectionsFilter) {
+		fmt.Fprintf(w, "%s%s (%d)\n", icon("📜 "), msg("Recent Events"), len(governance.RecentEvents))
+		for i, event := range governance.RecentEvents {
+			prefix := "├─"
+			if i == len(governance.RecentEvents)-1 {
+				prefix = "└─"
 			}
+			detail := ""
+			if event.Detail != "" {
+				detail = " " + event.Detail
+			}
+			fmt.Fprintf(w, "%s %s %s%s %s @%s\n", prefix, event.CreatedAt, event.Type, detail, msg("by"), event.Actor)
 		}
-		fmt.Println()
+		fmt.Fprintln(w)
+	} else if withAudit && shouldIncludeSectionOutput("recent-events", sectionsFilter) {
+		emptySection(w, icon("📜 ")+msg("Recent Events"))
 	}
 
 	return nil
 }
 
+// emptySection prints the header of a section without data followed by a
+// single placeholder line when --show-empty is set, so reports of different
+// repositories list the same sections; otherwise the section is omitted
+func emptySection(w io.Writer, header string) {
+	if showEmpty {
+		fmt.Fprintf(w, "%s\n└─ %s\n\n", header, msg("— none configured —"))
+	}
+}
+
+// refTargetPhrases spells out the special ref name tokens of ruleset
+// conditions
+var refTargetPhrases = map[string]string{
+	"~ALL":            "all branches",
+	"~DEFAULT_BRANCH": "the default branch",
+}
+
+// refTargetText describes the branches a ruleset applies to, such as
+// "main, release/* (except release/legacy)". Rulesets without conditions,
+// like classic branch protection, fall back to their pattern.
+func refTargetText(ruleset Ruleset) string {
+	if len(ruleset.RefNameInclude) == 0 {
+		return ruleset.Pattern
+	}
+	text := strings.Join(refNames(ruleset.RefNameInclude), ", ")
+	if len(ruleset.RefNameExclude) > 0 {
+		text += fmt.Sprintf(" (%s %s)", msg("except"), strings.Join(refNames(ruleset.RefNameExclude), ", "))
+	}
+	return text
+}
+
+// refNames shortens ref name conditions to branch names and phrases
+func refNames(refs []string) []string {
+	names := make([]string, 0, len(refs))
+	for _, ref := range refs {
+		if phrase, ok := refTargetPhrases[ref]; ok {
+			names = append(names, msg(phrase))
+		} else {
+			names = append(names, strings.TrimPrefix(ref, "refs/heads/"))
+		}
+	}
+	return names
+}
+
+// alertCount renders an open alert count, -1 meaning the count is unknown
+func alertCount(count *int) string {
+	if *count < 0 {
+		return msg("unknown")
+	}
+	return strconv.Itoa(*count)
+}
+
+// securitySource returns where a security setting's value came from;
+// reports saved before sources were tracked read as repository values
+func securitySource(security SecuritySettings, key string) string {
+	if source := security.Sources[key]; source != "" {
+		return source
+	}
+	return sourceRepo
+}
+
+// securityValue renders a security setting with its source when the value
+// didn't come from the repository itself
+func securityValue(security SecuritySettings, key string, value bool) string {
+	switch source := securitySource(security, key); source {
+	case sourceRepo:
+		return boolToIcon(value)
+	default:
+		return fmt.Sprintf("%s (%s)", boolToIcon(value), source)
+	}
+}
+
+// forkText names a fork's parent, and the network root when the parent is
+// itself a fork
+func forkText(settings RepositorySettings) string {
+	if settings.Source != "" && settings.Source != settings.Parent {
+		return fmt.Sprintf("%s (%s %s)", settings.Parent, msg("network root"), settings.Source)
+	}
+	return settings.Parent
+}
+
+// repoVisibility returns the reported visibility, falling back to Private for
+// servers and policy files that don't set it
+func repoVisibility(settings RepositorySettings) string {
+	switch {
+	case settings.Visibility != "":
+		return settings.Visibility
+	case settings.Private:
+		return "private"
+	default:
+		return "public"
+	}
+}
+
+// mergeQueueText describes a ruleset's merge queue, e.g. "✅ Yes (SQUASH, 1-5)"
+// for its merge method and the minimum and maximum entries merged together
+func mergeQueueText(mergeQueue *MergeQueue) string {
+	if mergeQueue == nil {
+		return boolToIcon(false)
+	}
+	return fmt.Sprintf("%s (%s, %d-%d)", boolToIcon(true), mergeQueue.MergeMethod, mergeQueue.MinEntriesToMerge, mergeQueue.MaxEntriesToMerge)
+}
+
+// communityLicense shows the SPDX ID next to the license check when known
+func communityLicense(community *CommunityProfile) string {
+	if community.License != "" {
+		return fmt.Sprintf("%s %s", boolToIcon(community.HasLicense), community.License)
+	}
+	return boolToIcon(community.HasLicense)
+}
+
 // shouldIncludeSectionOutput determines if a section should be included in output
 func shouldIncludeSectionOutput(section string, sectionsFilter []string) bool {
 	return utils.ShouldIncludeSection(sectionsFilter, section)
 }
 
+// ruleTypeText lists a rule type breakdown as "type: count" in type order
+func ruleTypeText(counts ruleTypeCounts, separator string) string {
+	types := make([]string, 0, len(counts))
+	for ruleType := range counts {
+		types = append(types, ruleType)
+	}
+	slices.Sort(types)
+
+	parts := make([]string, len(types))
+	for i, ruleType := range types {
+		parts[i] = fmt.Sprintf("%s: %d", ruleType, counts[ruleType])
+	}
+	return strings.Join(parts, separator)
+}
+
 func boolToIcon(b bool) string {
 	return utils.BoolToIcon(b)
 }
@@ -196,3 +2004,50 @@ func boolToIcon(b bool) string {
 func permissionToIcon(permission string) string {
 	return utils.PermissionToIcon(permission)
 }
+
+// mergeCommitFormat is one commit title/message default for rendering
+type mergeCommitFormat struct {
+	key, label, value string
+}
+
+// mergeCommitFormats lists the commit message defaults that are set, which
+// is only the case for allowed merge types
+func mergeCommitFormats(settings RepositorySettings) []mergeCommitFormat {
+	var formats []mergeCommitFormat
+	for _, format := range []mergeCommitFormat{
+		{"squash_merge_commit_title", "Squash Merge Commit Title", settings.SquashMergeCommitTitle},
+		{"squash_merge_commit_message", "Squash Merge Commit Message", settings.SquashMergeCommitMessage},
+		{"merge_commit_title", "Merge Commit Title", settings.MergeCommitTitle},
+		{"merge_commit_message", "Merge Commit Message", settings.MergeCommitMessage},
+	} {
+		if format.value != "" {
+			formats = append(formats, format)
+		}
+	}
+	return formats
+}
+
+// actionsEnabledText describes a possibly unknown Actions enablement
+func actionsEnabledText(enabled *bool) string {
+	if enabled == nil {
+		return "unknown"
+	}
+	return boolToIcon(*enabled)
+}
+
+// truncateToWidth shortens value so a table line made of value and the rest
+// of its text fits tableWidth. A zero tableWidth disables truncation.
+func truncateToWidth(value string, rest ...string) string {
+	if tableWidth <= 0 {
+		return value
+	}
+	return utils.Truncate(value, tableWidth-utf8.RuneCountInString(strings.Join(rest, "")))
+}
+
+func markdownCell(value string) string {
+	return utils.EscapeMarkdownCell(value)
+}
+
+func icon(symbol string) string {
+	return utils.Icon(symbol)
+}
@@ -0,0 +1,83 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// sectionScopes lists, per section, the classic token scopes of which one is
+// needed to read it. Parent scopes imply their read variants, so those are
+// listed too; repo covers everything a repository section reads. Sections
+// not listed work with any token on public repositories.
+var sectionScopes = map[string][]string{
+	"collaborators": {"repo"},
+	"security":      {"repo", "security_events"},
+	"webhooks":      {"repo", "admin:repo_hook", "write:repo_hook", "read:repo_hook"},
+	"deploy-keys":   {"repo"},
+	"actions":       {"repo"},
+}
+
+// checkTokenPermissions warns about the requested sections the token's
+// classic OAuth scopes, read from the X-OAuth-Scopes header of GET /user,
+// likely can't read, and fails with --strict-permissions. Fine-grained and
+// GitHub App tokens have no scopes to compare, so the check is skipped for
+// them. Without --strict-permissions the check never fails the run.
+func checkTokenPermissions() error {
+	ctx, cancel := inspectContext()
+	defer cancel()
+	client, err := newRESTClient(ctx)
+	if err != nil {
+		return err
+	}
+
+	headers, err := responseHeaders(client, "user", nil)
+	if err != nil {
+		if isAppScopeForbidden(err) {
+			logger.Info("GitHub App installation tokens have no OAuth scopes, skipping the permission precheck")
+			return nil
+		}
+		if strictPermissions {
+			return fmt.Errorf("failed to check token permissions: %v", err)
+		}
+		logger.Warn(fmt.Sprintf("failed to check token permissions: %v", err))
+		return nil
+	}
+	header, ok := headers[http.CanonicalHeaderKey("X-OAuth-Scopes")]
+	if !ok {
+		logger.Info("the token reports no OAuth scopes (fine-grained tokens have none), skipping the permission precheck")
+		return nil
+	}
+	var scopes []string
+	for _, scope := range strings.Split(strings.Join(header, ","), ",") {
+		if scope = strings.TrimSpace(scope); scope != "" {
+			scopes = append(scopes, scope)
+		}
+	}
+
+	var missing []string
+	for section, accepted := range sectionScopes {
+		if !shouldIncludeSection(section) {
+			continue
+		}
+		if !slices.ContainsFunc(accepted, func(scope string) bool { return slices.Contains(scopes, scope) }) {
+			missing = append(missing, fmt.Sprintf("%s (needs %s)", section, strings.Join(accepted, " or ")))
+		}
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+	sort.Strings(missing)
+
+	granted := strings.Join(scopes, ", ")
+	if granted == "" {
+		granted = "none"
+	}
+	if strictPermissions {
+		return fmt.Errorf("the token's scopes (%s) don't cover these sections: %s", granted, strings.Join(missing, ", "))
+	}
+	logNotice(fmt.Sprintf("the token's scopes (%s) likely can't read these sections, expect them to be empty or fail: %s", granted, strings.Join(missing, ", ")))
+	return nil
+}
@@ -0,0 +1,62 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/cli/go-gh/v2/pkg/api"
+)
+
+// RateLimitStatus is the remaining quota of the REST and GraphQL APIs
+type RateLimitStatus struct {
+	Core    RateLimit `json:"core"`
+	GraphQL RateLimit `json:"graphql"`
+}
+
+// RateLimit is one resource of GET /rate_limit; Reset is a Unix timestamp
+type RateLimit struct {
+	Limit     int   `json:"limit"`
+	Remaining int   `json:"remaining"`
+	Reset     int64 `json:"reset"`
+}
+
+// getRateLimit queries the current quota. It bypasses the response cache
+// since a cached answer would be stale by definition; GitHub doesn't count
+// this call against the limit.
+func getRateLimit() (*RateLimitStatus, error) {
+	opts := clientOptions()
+	opts.Headers["Accept"] = restAcceptHeader
+	client, err := api.NewRESTClient(opts)
+	if err != nil {
+		return nil, authError(err)
+	}
+
+	var response struct {
+		Resources RateLimitStatus `json:"resources"`
+	}
+	if err := client.Get("rate_limit", &response); err != nil {
+		return nil, err
+	}
+	return &response.Resources, nil
+}
+
+// printRateLimit reports the remaining quota to stderr for --show-rate-limit.
+// Failures are only warned about so they never change the exit code.
+func printRateLimit(label string) {
+	status, err := getRateLimit()
+	if err != nil {
+		logger.Warn(fmt.Sprintf("failed to fetch rate limit: %v", err))
+		return
+	}
+
+	prefix := "Rate limit"
+	if label != "" {
+		prefix = fmt.Sprintf("Rate limit after %s", label)
+	}
+	fmt.Fprintf(os.Stderr, "%s: core %s, graphql %s\n", prefix, status.Core, status.GraphQL)
+}
+
+func (r RateLimit) String() string {
+	return fmt.Sprintf("%d/%d remaining (resets %s)", r.Remaining, r.Limit, time.Unix(r.Reset, 0).Local().Format("15:04:05"))
+}
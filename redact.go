@@ -0,0 +1,182 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// redactMask replaces every redacted value
+const redactMask = "***"
+
+// redactCategoryNames are the accepted --redact values
+var redactCategoryNames = []string{"logins", "urls", "emails"}
+
+func validateRedactCategories(categories []string) error {
+	for _, category := range categories {
+		if !slices.Contains(redactCategoryNames, category) {
+			return fmt.Errorf("unknown --redact category %q (available: %s)", category, strings.Join(redactCategoryNames, ", "))
+		}
+	}
+	return nil
+}
+
+// redact returns a copy of governance with the string fields of the given
+// categories masked, leaving the original intact for compliance checks.
+// Team, app and role entries are not logins and are kept.
+func redact(governance *GovernanceConfig, categories []string) *GovernanceConfig {
+	if len(categories) == 0 {
+		return governance
+	}
+
+	// A JSON round trip is a deep copy since every field is exported
+	data, err := json.Marshal(governance)
+	if err != nil {
+		return governance
+	}
+	masked := &GovernanceConfig{}
+	if err := json.Unmarshal(data, masked); err != nil {
+		return governance
+	}
+
+	logins := slices.Contains(categories, "logins")
+	urls := slices.Contains(categories, "urls")
+	emails := slices.Contains(categories, "emails")
+
+	if logins {
+		for i := range masked.Collaborators {
+			masked.Collaborators[i].Login = redactMask
+		}
+		for i := range masked.Environments {
+			redactLogins(masked.Environments[i].Reviewers)
+		}
+		for i := range masked.ProtectedBranches {
+			redactLogins(masked.ProtectedBranches[i].Restrictions)
+		}
+		for i := range masked.RecentEvents {
+			masked.RecentEvents[i].Actor = redactMask
+			if strings.HasPrefix(masked.RecentEvents[i].Type, "member_") {
+				masked.RecentEvents[i].Detail = redactMask
+			}
+		}
+	}
+
+	if masked.CodeOwners != nil {
+		for i := range masked.CodeOwners.Entries {
+			for j, owner := range masked.CodeOwners.Entries[i].Owners {
+				if (emails && isEmailOwner(owner)) || (logins && isUserOwner(owner)) {
+					masked.CodeOwners.Entries[i].Owners[j] = redactMask
+				}
+			}
+		}
+	}
+
+	if urls {
+		for i := range masked.Webhooks {
+			masked.Webhooks[i].URL = redactMask
+		}
+		for i := range masked.Autolinks {
+			masked.Autolinks[i].URLTemplate = redactMask
+		}
+		if masked.Pages != nil && masked.Pages.CustomDomain != "" {
+			masked.Pages.CustomDomain = redactMask
+		}
+	}
+
+	masked.Violations = redactViolations(masked.Violations, categories)
+
+	return masked
+}
+
+// redactLogins masks the user entries of a mixed actor list
+func redactLogins(actors []string) {
+	for i, actor := range actors {
+		if isLoginActor(actor) {
+			actors[i] = redactMask
+		}
+	}
+}
+
+// isLoginActor reports whether an actor list entry is a user, where teams are
+// "@slug" and apps "app:slug"
+func isLoginActor(actor string) bool {
+	return !strings.HasPrefix(actor, "@") && !strings.Contains(actor, ":")
+}
+
+// isUserOwner reports whether a CODEOWNERS owner is a user, not "@org/team"
+func isUserOwner(owner string) bool {
+	return strings.HasPrefix(owner, "@") && !strings.Contains(owner, "/")
+}
+
+func isEmailOwner(owner string) bool {
+	return !strings.HasPrefix(owner, "@") && strings.Contains(owner, "@")
+}
+
+// violationRedaction locates a masked field in a compliance path. Under
+// prefix, field is followed either by an element key, masked when sensitive
+// accepts it (nil accepts all), or by nothing, when the actual value is the
+// field itself. An empty field means the element key directly after prefix.
+type violationRedaction struct {
+	category  string
+	prefix    string
+	field     string
+	sensitive func(key string) bool
+}
+
+var violationRedactions = []violationRedaction{
+	{category: "logins", prefix: "collaborators"},
+	{category: "logins", prefix: "environments[", field: ".reviewers", sensitive: isLoginActor},
+	{category: "logins", prefix: "protected_branches[", field: ".restrictions", sensitive: isLoginActor},
+	{category: "logins", prefix: "recent_events[", field: ".actor"},
+	{category: "logins", prefix: "recent_events[", field: ".detail"},
+	{category: "logins", prefix: "codeowners.entries[", field: ".owners", sensitive: isUserOwner},
+	{category: "emails", prefix: "codeowners.entries[", field: ".owners", sensitive: isEmailOwner},
+	{category: "urls", prefix: "webhooks[", field: ".url"},
+	{category: "urls", prefix: "autolinks[", field: ".url_template"},
+	{category: "urls", prefix: "pages", field: ".custom_domain"},
+}
+
+// redactViolations returns a copy of violations with the masked fields of the
+// given categories hidden from both the path and the actual value. Presence
+// values are kept since they don't reveal anything.
+func redactViolations(violations []Violation, categories []string) []Violation {
+	if len(categories) == 0 || violations == nil {
+		return violations
+	}
+
+	masked := make([]Violation, len(violations))
+	for i, violation := range violations {
+		for _, rule := range violationRedactions {
+			if !slices.Contains(categories, rule.category) || !strings.HasPrefix(violation.Path, rule.prefix) {
+				continue
+			}
+			at := len(rule.prefix)
+			if rule.field != "" {
+				index := strings.Index(violation.Path[at:], rule.field)
+				if index < 0 {
+					continue
+				}
+				at += index + len(rule.field)
+			}
+
+			tail := violation.Path[at:]
+			switch {
+			case tail == "":
+				if violation.Actual != presence(true) && violation.Actual != presence(false) {
+					violation.Actual = redactMask
+				}
+			case strings.HasPrefix(tail, "["):
+				end := strings.Index(tail, "]")
+				if end < 0 {
+					continue
+				}
+				if key := tail[1:end]; key != redactMask && (rule.sensitive == nil || rule.sensitive(key)) {
+					violation.Path = violation.Path[:at] + "[" + redactMask + "]" + tail[end+1:]
+				}
+			}
+		}
+		masked[i] = violation
+	}
+	return masked
+}
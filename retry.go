@@ -0,0 +1,66 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/cli/go-gh/v2/pkg/api"
+	"github.com/jefeish/gh-repo-inspect/utils"
+)
+
+// retryingClient retries GET requests that hit GitHub's primary or secondary
+// rate limits, waiting as long as the response asks before each attempt
+type retryingClient struct {
+	client     apiClient
+	maxRetries int
+}
+
+func newRetryingClient(client apiClient, maxRetries int) *retryingClient {
+	return &retryingClient{client: client, maxRetries: maxRetries}
+}
+
+func (c *retryingClient) requestContext() context.Context {
+	return requestContext(c.client)
+}
+
+func (c *retryingClient) responseHeaders(path string, body interface{}) (http.Header, error) {
+	return responseHeaders(c.client, path, body)
+}
+
+func (c *retryingClient) Get(path string, response interface{}) error {
+	for attempt := 0; ; attempt++ {
+		err := c.client.Get(path, response)
+		if err == nil || attempt >= c.maxRetries {
+			return err
+		}
+
+		var httpErr *api.HTTPError
+		if !errors.As(err, &httpErr) || !isRateLimited(httpErr) {
+			return err
+		}
+
+		delay := utils.RetryDelay(httpErr.Headers.Get("Retry-After"), httpErr.Headers.Get("X-RateLimit-Reset"), attempt, time.Now())
+		logger.Info(fmt.Sprintf("Rate limited on %s, retrying in %s (attempt %d of %d)", path, delay, attempt+1, c.maxRetries))
+		// A wait past --timeout would only end in a cancelled request
+		select {
+		case <-time.After(delay):
+		case <-c.requestContext().Done():
+			return err
+		}
+	}
+}
+
+// isRateLimited reports whether a response was rejected by a rate limit rather
+// than for lack of permission; plain 403s are left to the callers
+func isRateLimited(err *api.HTTPError) bool {
+	switch err.StatusCode {
+	case http.StatusTooManyRequests:
+		return true
+	case http.StatusForbidden:
+		return err.Headers.Get("Retry-After") != "" || err.Headers.Get("X-RateLimit-Remaining") == "0"
+	}
+	return false
+}
@@ -0,0 +1,114 @@
+package main
+
+import (
+	"fmt"
+	"io"
+)
+
+const (
+	sarifSchema  = "https://json.schemastore.org/sarif-2.1.0.json"
+	sarifVersion = "2.1.0"
+)
+
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	InformationURI string      `json:"informationUri"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID                   string             `json:"id"`
+	ShortDescription     sarifMessage       `json:"shortDescription"`
+	DefaultConfiguration sarifConfiguration `json:"defaultConfiguration"`
+}
+
+type sarifConfiguration struct {
+	Level string `json:"level"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifResult struct {
+	RuleID              string            `json:"ruleId"`
+	Level               string            `json:"level"`
+	Message             sarifMessage      `json:"message"`
+	Locations           []sarifLocation   `json:"locations"`
+	PartialFingerprints map[string]string `json:"partialFingerprints"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+// outputSARIF reports every fail condition that matches, across all
+// governances, as SARIF 2.1.0 results of a single run. The raw configuration
+// is not included.
+func outputSARIF(w io.Writer, governances []*GovernanceConfig) error {
+	driver := sarifDriver{
+		Name:           "gh-repo-inspect",
+		InformationURI: "https://github.com/jefeish/gh-repo-inspect",
+	}
+	for _, condition := range failConditions {
+		driver.Rules = append(driver.Rules, sarifRule{
+			ID:                   condition.key,
+			ShortDescription:     sarifMessage{Text: condition.description},
+			DefaultConfiguration: sarifConfiguration{Level: condition.level},
+		})
+	}
+
+	results := []sarifResult{}
+	for _, governance := range governances {
+		repo := fmt.Sprintf("%s/%s", governance.Repository.Owner, governance.Repository.Name)
+		for _, key := range checkFailConditions(governance, allFailConditionKeys()) {
+			condition := failConditionByKey(key)
+			results = append(results, sarifResult{
+				RuleID:    condition.key,
+				Level:     condition.level,
+				Message:   sarifMessage{Text: fmt.Sprintf("%s in %s", condition.description, repo)},
+				Locations: []sarifLocation{{PhysicalLocation: sarifPhysicalLocation{ArtifactLocation: sarifArtifactLocation{URI: repositoryURL(governance.Repository)}}}},
+				// Keeps alerts stable across uploads, since there is no file content to hash
+				PartialFingerprints: map[string]string{"governanceFinding/v1": repo + ":" + condition.key},
+			})
+		}
+	}
+
+	return outputJSON(w, sarifLog{
+		Schema:  sarifSchema,
+		Version: sarifVersion,
+		Runs:    []sarifRun{{Tool: sarifTool{Driver: driver}, Results: results}},
+	})
+}
+
+// repositoryURL builds the web URL of a repository on the resolved host
+func repositoryURL(repo RepoInfo) string {
+	targetHost := resolveHost()
+	if targetHost == "" {
+		targetHost = "github.com"
+	}
+	return fmt.Sprintf("https://%s/%s/%s", targetHost, repo.Owner, repo.Name)
+}
@@ -0,0 +1,122 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"reflect"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+const jsonSchemaDraft = "https://json-schema.org/draft/2020-12/schema"
+
+// schemaEnums lists the known values of string fields, keyed by
+// "<Type>.<Field>"
+var schemaEnums = map[string][]string{
+	"Collaborator.Permission":                     {"admin", "maintain", "write", "triage", "read"},
+	"Collaborator.Source":                         {"direct", "team", "organization"},
+	"Ruleset.Source":                              {"repo", "organization"},
+	"MergeQueue.MergeMethod":                      {"MERGE", "SQUASH", "REBASE"},
+	"Ruleset.Enforcement":                         {"active", "evaluate", "disabled"},
+	"RepositorySettings.Visibility":               {"public", "private", "internal"},
+	"TagRule.Enforcement":                         {"active", "evaluate", "disabled"},
+	"Violation.Severity":                          {"low", "medium", "high"},
+	"RepositorySettings.SquashMergeCommitTitle":   {"PR_TITLE", "COMMIT_OR_PR_TITLE"},
+	"RepositorySettings.SquashMergeCommitMessage": {"PR_BODY", "COMMIT_MESSAGES", "BLANK"},
+	"RepositorySettings.MergeCommitTitle":         {"PR_TITLE", "MERGE_MESSAGE"},
+	"RepositorySettings.MergeCommitMessage":       {"PR_BODY", "PR_TITLE", "BLANK"},
+}
+
+func newSchemaCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "schema",
+		Short: "Print the JSON Schema of the governance report",
+		Long: `Print a JSON Schema (draft 2020-12) describing the JSON and YAML reports,
+derived from the report types so it always matches the current output.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			encoder := json.NewEncoder(os.Stdout)
+			encoder.SetIndent("", "  ")
+			return encoder.Encode(governanceSchema())
+		},
+	}
+}
+
+// governanceSchema builds the schema for GovernanceConfig, with every nested
+// struct type emitted once under $defs
+func governanceSchema() map[string]interface{} {
+	defs := map[string]interface{}{}
+	root := structSchema(reflect.TypeOf(GovernanceConfig{}), defs)
+	root["$schema"] = jsonSchemaDraft
+	root["title"] = "GovernanceConfig"
+	root["$defs"] = defs
+	return root
+}
+
+func structSchema(t reflect.Type, defs map[string]interface{}) map[string]interface{} {
+	properties := map[string]interface{}{}
+	required := []string{}
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		name, options, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		property := typeSchema(field.Type, defs)
+		if values, ok := schemaEnums[t.Name()+"."+field.Name]; ok {
+			property["enum"] = values
+		}
+		properties[name] = property
+
+		if !strings.Contains(options, "omitempty") {
+			required = append(required, name)
+		}
+	}
+
+	return map[string]interface{}{
+		"type":                 "object",
+		"properties":           properties,
+		"required":             required,
+		"additionalProperties": false,
+	}
+}
+
+func typeSchema(t reflect.Type, defs map[string]interface{}) map[string]interface{} {
+	switch t.Kind() {
+	case reflect.Ptr:
+		// Nil pointers encode as null
+		return map[string]interface{}{"anyOf": []interface{}{typeSchema(t.Elem(), defs), map[string]interface{}{"type": "null"}}}
+	case reflect.Struct:
+		if _, ok := defs[t.Name()]; !ok {
+			// Reserve the name first so recursive types terminate
+			defs[t.Name()] = nil
+			defs[t.Name()] = structSchema(t, defs)
+		}
+		return map[string]interface{}{"$ref": "#/$defs/" + t.Name()}
+	case reflect.Slice, reflect.Array:
+		// Nil slices and maps encode as null
+		return map[string]interface{}{"type": []string{"array", "null"}, "items": typeSchema(t.Elem(), defs)}
+	case reflect.Map:
+		return map[string]interface{}{"type": []string{"object", "null"}, "additionalProperties": typeSchema(t.Elem(), defs)}
+	case reflect.Bool:
+		return map[string]interface{}{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]interface{}{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return map[string]interface{}{"type": "number"}
+	case reflect.String:
+		return map[string]interface{}{"type": "string"}
+	}
+	return map[string]interface{}{}
+}
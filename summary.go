@@ -0,0 +1,177 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"text/tabwriter"
+)
+
+// maxAdmins is the number of admin collaborators above which the risk score
+// counts the repository as having too many admins
+const maxAdmins = 3
+
+// riskWeights assigns each --fail-on condition its share of the risk score.
+// The weights plus the admin penalty add up to 100, so a repository matching
+// every condition scores 100 and one matching none scores 0:
+//
+//	no-branch-protection     25
+//	no-secret-scanning       20
+//	no-vulnerability-alerts  15
+//	no-push-protection       10
+//	no-required-reviews      10
+//	allows-force-push        10
+//	allows-deletions          5
+//	more than 3 admins        5
+//
+// Conditions whose section was not inspected do not contribute.
+var riskWeights = map[string]int{
+	"no-branch-protection":    25,
+	"no-secret-scanning":      20,
+	"no-vulnerability-alerts": 15,
+	"no-push-protection":      10,
+	"no-required-reviews":     10,
+	"allows-force-push":       10,
+	"allows-deletions":        5,
+}
+
+const tooManyAdminsWeight = 5
+
+func computeSummary(governance *GovernanceConfig) *Summary {
+	summary := &Summary{
+		ProtectedBranchCount: len(governance.ProtectedBranches),
+		RulesetCount:         len(governance.Rulesets),
+		HasSecretScanning:    governance.SecuritySettings.SecretScanning,
+		CollaboratorCount:    len(governance.Collaborators),
+		// Rulesets matching only e.g. release/* leave the default branch open
+		DefaultBranchProtected: defaultBranchProtected(governance),
+	}
+
+	for _, collab := range governance.Collaborators {
+		if collab.Permission == "admin" {
+			summary.AdminCount++
+		}
+	}
+
+	for _, ruleset := range governance.Rulesets {
+		if len(ruleset.BypassActors) > 0 {
+			summary.BypassableRulesets = append(summary.BypassableRulesets, ruleset.Name)
+		}
+	}
+
+	seen := map[string]bool{}
+	for _, ruleset := range activeRulesets(governance) {
+		for _, check := range ruleset.OrphanedChecks {
+			if !seen[check] {
+				seen[check] = true
+				summary.OrphanedChecks = append(summary.OrphanedChecks, check)
+			}
+		}
+
+		// A ruleset counts once per type, however many rules of it it has
+		counted := map[string]bool{}
+		for _, ruleType := range ruleset.RuleTypes {
+			if counted[ruleType] {
+				continue
+			}
+			counted[ruleType] = true
+			if summary.RuleTypeBreakdown == nil {
+				summary.RuleTypeBreakdown = ruleTypeCounts{}
+			}
+			summary.RuleTypeBreakdown[ruleType]++
+		}
+	}
+	sort.Strings(summary.OrphanedChecks)
+
+	for _, milestone := range governance.Milestones {
+		if milestone.State == "open" {
+			summary.OpenMilestoneCount++
+		}
+	}
+
+	keys := make([]string, 0, len(riskWeights))
+	for key := range riskWeights {
+		keys = append(keys, key)
+	}
+	for _, key := range checkFailConditions(governance, keys) {
+		summary.RiskScore += riskWeights[key]
+	}
+	if summary.AdminCount > maxAdmins {
+		summary.RiskScore += tooManyAdminsWeight
+	}
+
+	return summary
+}
+
+// fleetRow is one repository in the --format summary roll-up
+type fleetRow struct {
+	Repo           string `json:"repo"`
+	Visibility     string `json:"visibility"`
+	DefaultBranch  string `json:"default_branch"`
+	RulesetCount   int    `json:"ruleset_count"`
+	SecretScanning bool   `json:"secret_scanning"`
+	Protected      bool   `json:"protected"`
+	RiskScore      int    `json:"risk_score"`
+}
+
+// fleetRows builds the roll-up rows sorted by repository name
+func fleetRows(governances []*GovernanceConfig) []fleetRow {
+	rows := make([]fleetRow, 0, len(governances))
+	for _, governance := range governances {
+		summary := governance.Summary
+		if summary == nil {
+			summary = computeSummary(governance)
+		}
+
+		rows = append(rows, fleetRow{
+			Repo:           governance.Repository.Owner + "/" + governance.Repository.Name,
+			Visibility:     repoVisibility(governance.RepoSettings),
+			DefaultBranch:  governance.RepoSettings.DefaultBranch,
+			RulesetCount:   summary.RulesetCount,
+			SecretScanning: summary.HasSecretScanning,
+			Protected:      summary.RulesetCount > 0 || summary.ProtectedBranchCount > 0,
+			RiskScore:      summary.RiskScore,
+		})
+	}
+
+	sort.Slice(rows, func(i, j int) bool {
+		return rows[i].Repo < rows[j].Repo
+	})
+	return rows
+}
+
+// yesNo renders a boolean without icons, whose display width would throw off
+// the column alignment
+func yesNo(value bool) string {
+	if value {
+		return "yes"
+	}
+	return "no"
+}
+
+// outputFleetSummary prints one aligned row per repository followed by a
+// totals row
+func outputFleetSummary(w io.Writer, governances []*GovernanceConfig) error {
+	rows := fleetRows(governances)
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "REPO\tVISIBILITY\tDEFAULT BRANCH\tRULESETS\tSECRET SCANNING\tPROTECTED\tRISK")
+
+	var rulesets, scanning, protected, private int
+	for _, row := range rows {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%d\t%s\t%s\t%d\n", row.Repo, row.Visibility, row.DefaultBranch, row.RulesetCount, yesNo(row.SecretScanning), yesNo(row.Protected), row.RiskScore)
+		rulesets += row.RulesetCount
+		if row.SecretScanning {
+			scanning++
+		}
+		if row.Protected {
+			protected++
+		}
+		if row.Visibility == "private" {
+			private++
+		}
+	}
+
+	fmt.Fprintf(tw, "TOTAL (%d)\t%d private\t\t%d\t%d/%d\t%d/%d\t\n", len(rows), private, rulesets, scanning, len(rows), protected, len(rows))
+	return tw.Flush()
+}
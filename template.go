@@ -0,0 +1,64 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/template"
+)
+
+// reportTemplate is the parsed --template, nil when the flag is unset
+var reportTemplate *template.Template
+
+// templateFuncs are the helpers available to --template, named after the
+// output helpers they wrap
+var templateFuncs = template.FuncMap{
+	"boolIcon": boolToIcon,
+	"yesno":    yesNo,
+	// join separates with ", " unless a separator is given
+	"join": func(values []string, separator ...string) string {
+		if len(separator) > 0 {
+			return strings.Join(values, separator[0])
+		}
+		return strings.Join(values, ", ")
+	},
+	"actors":     bypassActorStrings,
+	"target":     refTargetText,
+	"visibility": repoVisibility,
+	"json": func(value interface{}) (string, error) {
+		data, err := json.Marshal(value)
+		return string(data), err
+	},
+}
+
+// parseReportTemplate parses --template, which is either the template text
+// or @path of a file holding it, before any API calls are made. Parse errors
+// name the template ("--template" or the file) and the offending line.
+func parseReportTemplate(value string) error {
+	name, text := "--template", value
+	if path, ok := strings.CutPrefix(value, "@"); ok {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("failed to read template file: %v", err)
+		}
+		name, text = path, string(data)
+	}
+
+	tmpl, err := template.New(name).Funcs(templateFuncs).Parse(text)
+	if err != nil {
+		return fmt.Errorf("invalid template: %v", err)
+	}
+	reportTemplate = tmpl
+	return nil
+}
+
+// outputTemplate executes --template against one report. Execution errors
+// carry the line and column of the failing action.
+func outputTemplate(w io.Writer, governance *GovernanceConfig) error {
+	if err := reportTemplate.Execute(w, governance); err != nil {
+		return fmt.Errorf("failed to execute template: %v", err)
+	}
+	return nil
+}
@@ -0,0 +1,81 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/cli/go-gh/v2/pkg/api"
+)
+
+// contextClient sends every request with ctx, so a hung request is
+// cancelled once the repository's --timeout runs out
+type contextClient struct {
+	client *api.RESTClient
+	ctx    context.Context
+}
+
+func (c *contextClient) Get(path string, response interface{}) error {
+	return c.client.DoWithContext(c.ctx, http.MethodGet, path, nil, response)
+}
+
+func (c *contextClient) requestContext() context.Context {
+	return c.ctx
+}
+
+func (c *contextClient) responseHeaders(path string, body interface{}) (http.Header, error) {
+	response, err := c.client.RequestWithContext(c.ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+	if body != nil {
+		if err := json.NewDecoder(response.Body).Decode(body); err != nil {
+			return nil, err
+		}
+	}
+	return response.Header, nil
+}
+
+// contextCarrier is implemented by the clients that carry a request context,
+// directly or through the client they wrap
+type contextCarrier interface {
+	requestContext() context.Context
+}
+
+// requestContext returns the context the client's requests are sent with,
+// for requests made outside of it such as GraphQL queries. Clients without
+// one (the dry-run recorder) never time out.
+func requestContext(client apiClient) context.Context {
+	if carrier, ok := client.(contextCarrier); ok {
+		return carrier.requestContext()
+	}
+	return context.Background()
+}
+
+// headerCarrier is implemented by the clients that can return the headers
+// of a response, directly or through the client they wrap
+type headerCarrier interface {
+	responseHeaders(path string, body interface{}) (http.Header, error)
+}
+
+// responseHeaders makes a GET request, decoding the response into body unless
+// it is nil, and returns the response headers, which Get doesn't expose. The
+// request bypasses the cache and retries so the headers are always current.
+// Clients without headers (the dry-run recorder) answer through Get with
+// none.
+func responseHeaders(client apiClient, path string, body interface{}) (http.Header, error) {
+	if carrier, ok := client.(headerCarrier); ok {
+		return carrier.responseHeaders(path, body)
+	}
+	return http.Header{}, client.Get(path, body)
+}
+
+// inspectContext bounds one repository's inspection by --timeout, where 0
+// means no limit
+func inspectContext() (context.Context, context.CancelFunc) {
+	if inspectTimeout <= 0 {
+		return context.WithCancel(context.Background())
+	}
+	return context.WithTimeout(context.Background(), inspectTimeout)
+}
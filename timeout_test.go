@@ -0,0 +1,36 @@
+package main
+
+import (
+	"context"
+	"testing"
+)
+
+func TestRESTClientCarriesContext(t *testing.T) {
+	savedToken, savedRetries, savedCacheDir := authToken, maxRetries, cacheDir
+	defer func() { authToken, maxRetries, cacheDir = savedToken, savedRetries, savedCacheDir }()
+	authToken = "test-token"
+
+	type key struct{}
+	ctx := context.WithValue(context.Background(), key{}, "inspect")
+
+	tests := []struct {
+		name     string
+		retries  int
+		cacheDir string
+	}{
+		{"plain", 0, ""},
+		{"retrying", 3, ""},
+		{"retrying and caching", 3, t.TempDir()},
+	}
+
+	for _, tt := range tests {
+		maxRetries, cacheDir = tt.retries, tt.cacheDir
+		client, err := newRESTClient(ctx)
+		if err != nil {
+			t.Fatalf("%s: newRESTClient: %v", tt.name, err)
+		}
+		if got := requestContext(client); got != ctx {
+			t.Errorf("%s: requestContext() = %v, want the inspection context", tt.name, got)
+		}
+	}
+}
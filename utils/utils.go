@@ -1,5 +1,13 @@
 package utils
 
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
 // ShouldIncludeSection determines if a section should be included based on the sections filter
 func ShouldIncludeSection(sections []string, section string) bool {
 	if len(sections) == 0 {
@@ -13,8 +21,18 @@ func ShouldIncludeSection(sections []string, section string) bool {
 	return false
 }
 
+// PlainOutput makes the icon helpers return plain text, for output that is
+// not going to a terminal or when --no-color is set
+var PlainOutput bool
+
 // BoolToIcon converts a boolean to a human-readable icon string
 func BoolToIcon(b bool) string {
+	if PlainOutput {
+		if b {
+			return "yes"
+		}
+		return "no"
+	}
 	if b {
 		return "✅ Yes"
 	}
@@ -23,6 +41,16 @@ func BoolToIcon(b bool) string {
 
 // PermissionToIcon converts a permission string to a human-readable icon string
 func PermissionToIcon(permission string) string {
+	if PlainOutput {
+		switch permission {
+		case "push":
+			return "write"
+		case "pull":
+			return "read"
+		default:
+			return permission
+		}
+	}
 	switch permission {
 	case "admin":
 		return "🔑 Admin"
@@ -37,4 +65,263 @@ func PermissionToIcon(permission string) string {
 	default:
 		return "❓ " + permission
 	}
-}
\ No newline at end of file
+}
+
+// ParseGitRemote extracts host, owner and name from a git remote URL. Besides
+// the forms ParseRepoArg accepts it handles scp-like "git@host:owner/repo.git"
+// and "ssh://git@host/owner/repo.git" remotes.
+func ParseGitRemote(remote string) (host, owner, repo string, err error) {
+	trimmed := strings.TrimSpace(remote)
+	if scheme := strings.Index(trimmed, "://"); scheme >= 0 {
+		trimmed = trimmed[scheme+3:]
+	} else if colon := strings.Index(trimmed, ":"); colon >= 0 {
+		// scp-like syntax separates the host from the path with a colon
+		trimmed = trimmed[:colon] + "/" + trimmed[colon+1:]
+	}
+	if at := strings.Index(trimmed, "@"); at >= 0 {
+		trimmed = trimmed[at+1:]
+	}
+
+	slash := strings.Index(trimmed, "/")
+	if slash <= 0 {
+		return "", "", "", fmt.Errorf("unrecognized git remote %q", remote)
+	}
+	// Drop any ssh port from the host
+	host = trimmed[:slash]
+	if colon := strings.Index(host, ":"); colon >= 0 {
+		host = host[:colon]
+	}
+
+	_, owner, repo, err = ParseRepoArg(trimmed[slash+1:])
+	if err != nil {
+		return "", "", "", fmt.Errorf("unrecognized git remote %q", remote)
+	}
+
+	return host, owner, repo, nil
+}
+
+// RetryDelay returns how long to wait before retry attempt (zero-based) of a
+// rate-limited request. Retry-After seconds take precedence, then the
+// X-RateLimit-Reset epoch; otherwise the delay doubles from one second.
+func RetryDelay(retryAfter, rateLimitReset string, attempt int, now time.Time) time.Duration {
+	if seconds, err := strconv.Atoi(retryAfter); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second
+	}
+	if reset, err := strconv.ParseInt(rateLimitReset, 10, 64); err == nil {
+		if wait := time.Unix(reset, 0).Sub(now); wait > 0 {
+			return wait.Truncate(time.Second) + time.Second
+		}
+		return 0
+	}
+	return time.Second << attempt
+}
+
+// Icon returns a decorative icon, or an empty string in plain mode
+func Icon(symbol string) string {
+	if PlainOutput {
+		return ""
+	}
+	return symbol
+}
+
+// EscapeMarkdownCell makes a value safe to place inside a Markdown table cell
+func EscapeMarkdownCell(value string) string {
+	value = strings.ReplaceAll(value, "|", "\\|")
+	value = strings.ReplaceAll(value, "\r\n", " ")
+	return strings.ReplaceAll(value, "\n", " ")
+}
+
+// PermissionRank orders repository permissions by privilege, from 5 for admin
+// down to 1 for read. Unknown permissions rank 0.
+func PermissionRank(permission string) int {
+	switch permission {
+	case "admin":
+		return 5
+	case "maintain":
+		return 4
+	case "write", "push":
+		return 3
+	case "triage":
+		return 2
+	case "read", "pull":
+		return 1
+	default:
+		return 0
+	}
+}
+
+// Truncate shortens value to at most max characters, ending it with an
+// ellipsis when it was cut. It counts runes, so multibyte characters are
+// never split.
+func Truncate(value string, max int) string {
+	runes := []rune(value)
+	if len(runes) <= max {
+		return value
+	}
+	if max < 1 {
+		return "…"
+	}
+	return string(runes[:max-1]) + "…"
+}
+
+// ParseRepoArg splits a repository argument into host, owner and name.
+// It accepts "owner/repo" as well as "host/owner/repo" and full URLs such as
+// "https://github.example.com/owner/repo.git"; host is empty when not given.
+func ParseRepoArg(arg string) (host, owner, repo string, err error) {
+	trimmed := strings.TrimSpace(arg)
+	for _, scheme := range []string{"https://", "http://"} {
+		trimmed = strings.TrimPrefix(trimmed, scheme)
+	}
+	trimmed = strings.TrimSuffix(strings.TrimSuffix(trimmed, "/"), ".git")
+
+	parts := strings.Split(trimmed, "/")
+	switch {
+	case len(parts) == 2:
+		owner, repo = parts[0], parts[1]
+	case len(parts) == 3:
+		host, owner, repo = parts[0], parts[1], parts[2]
+	}
+
+	if owner == "" || repo == "" {
+		return "", "", "", fmt.Errorf("repository must be in format 'owner/repo' or 'host/owner/repo'")
+	}
+
+	return host, owner, repo, nil
+}
+
+// ParseCodeOwnersLine splits a CODEOWNERS line into its path pattern and
+// owners. Blank lines and comments report ok=false.
+func ParseCodeOwnersLine(line string) (pattern string, owners []string, ok bool) {
+	if idx := strings.Index(line, "#"); idx >= 0 && (idx == 0 || line[idx-1] != '\\') {
+		line = line[:idx]
+	}
+
+	fields := strings.Fields(line)
+	if len(fields) == 0 {
+		return "", nil, false
+	}
+
+	return fields[0], fields[1:], true
+}
+
+// CheckNameMatches reports whether a check run or commit status name
+// satisfies a required status check. Required checks name the job or
+// status context rather than the workflow, so names are compared as is
+// (ignoring case and surrounding spaces), and a "Workflow / job" entry, the
+// form the checks UI displays, matches on its job part. Matrix jobs report
+// as "job (variant)" and don't satisfy a plain "job" requirement.
+func CheckNameMatches(required, name string) bool {
+	required = strings.ToLower(strings.TrimSpace(required))
+	name = strings.ToLower(strings.TrimSpace(name))
+	if required == name {
+		return true
+	}
+	if idx := strings.LastIndex(required, " / "); idx >= 0 {
+		return strings.TrimSpace(required[idx+3:]) == name
+	}
+	return false
+}
+
+// NormalizeTimestamp rewrites an API timestamp, which may carry fractional
+// seconds or a UTC offset, as RFC3339 in UTC. Values that don't parse are
+// returned unchanged.
+func NormalizeTimestamp(value string) string {
+	parsed, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return value
+	}
+	return parsed.UTC().Format(time.RFC3339)
+}
+
+// LastPage returns the page number of the rel="last" link of a Link header,
+// which with per_page=1 is the total number of items. ok is false when there
+// is no such link or it carries no page number, as with cursor pagination.
+func LastPage(link string) (page int, ok bool) {
+	for _, part := range strings.Split(link, ",") {
+		target, params, found := strings.Cut(part, ";")
+		if !found || !strings.Contains(params, `rel="last"`) {
+			continue
+		}
+		target = strings.Trim(strings.TrimSpace(target), "<>")
+		parsed, err := url.Parse(target)
+		if err != nil {
+			return 0, false
+		}
+		page, err := strconv.Atoi(parsed.Query().Get("page"))
+		if err != nil || page < 1 {
+			return 0, false
+		}
+		return page, true
+	}
+	return 0, false
+}
+
+// FlattenTree rewrites box-drawn tree output as one "Section > Field: value"
+// line per entry. Unindented lines without a value start a new section,
+// nested entries are prefixed with their parents' names, and blank lines,
+// rules and headings of nested lists are dropped.
+func FlattenTree(text string) string {
+	var out strings.Builder
+	section := ""
+	var parents []string
+	for _, line := range strings.Split(text, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.Trim(trimmed, "═") == "" {
+			continue
+		}
+
+		runes := []rune(line)
+		depth := -1
+		for i, r := range runes {
+			if (r == '├' || r == '└') && i+1 < len(runes) && runes[i+1] == '─' {
+				depth = i / 3
+				runes = runes[i+2:]
+				break
+			}
+			if r != ' ' && r != '│' {
+				break
+			}
+		}
+
+		entry := strings.TrimSpace(string(runes))
+		if depth < 0 {
+			if strings.Contains(entry, ": ") {
+				// A standalone line such as the repository name
+				section, parents = "", nil
+				out.WriteString(entry + "\n")
+			} else {
+				section, parents = entry, nil
+			}
+			continue
+		}
+
+		if depth > len(parents) {
+			depth = len(parents)
+		}
+		parents = append(parents[:depth], treeEntryName(entry))
+		if strings.HasSuffix(entry, ":") {
+			// Heading of a nested list; its entries carry the name
+			continue
+		}
+
+		path := append([]string{}, parents[:depth]...)
+		if section != "" {
+			path = append([]string{section}, path...)
+		}
+		out.WriteString(strings.Join(append(path, entry), " > ") + "\n")
+	}
+	return out.String()
+}
+
+// treeEntryName is the part of a tree entry its children are labelled with:
+// the field name of "Field: value" or the name of "name (details)"
+func treeEntryName(entry string) string {
+	entry = strings.TrimSuffix(entry, ":")
+	if idx := strings.Index(entry, ": "); idx >= 0 {
+		entry = entry[:idx]
+	}
+	if idx := strings.Index(entry, " ("); idx >= 0 {
+		entry = entry[:idx]
+	}
+	return entry
+}
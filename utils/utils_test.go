@@ -1,7 +1,9 @@
 package utils
 
 import (
+	"strings"
 	"testing"
+	"time"
 )
 
 func TestShouldIncludeSection(t *testing.T) {
@@ -105,4 +107,439 @@ func TestPermissionToIcon(t *testing.T) {
 			}
 		})
 	}
-}
\ No newline at end of file
+}
+
+func TestParseGitRemote(t *testing.T) {
+	tests := []struct {
+		name      string
+		remote    string
+		wantHost  string
+		wantOwner string
+		wantRepo  string
+		wantErr   bool
+	}{
+		{
+			name:      "https remote",
+			remote:    "https://github.com/cli/cli.git",
+			wantHost:  "github.com",
+			wantOwner: "cli",
+			wantRepo:  "cli",
+		},
+		{
+			name:      "scp-like ssh remote",
+			remote:    "git@github.com:jefeish/gh-repo-inspect.git",
+			wantHost:  "github.com",
+			wantOwner: "jefeish",
+			wantRepo:  "gh-repo-inspect",
+		},
+		{
+			name:      "ssh URL with port",
+			remote:    "ssh://git@github.example.com:2222/owner/repo.git",
+			wantHost:  "github.example.com",
+			wantOwner: "owner",
+			wantRepo:  "repo",
+		},
+		{
+			name:    "not a repository URL",
+			remote:  "git@github.com:owner",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			host, owner, repo, err := ParseGitRemote(tt.remote)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseGitRemote() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if host != tt.wantHost || owner != tt.wantOwner || repo != tt.wantRepo {
+				t.Errorf("ParseGitRemote() = %q, %q, %q, want %q, %q, %q", host, owner, repo, tt.wantHost, tt.wantOwner, tt.wantRepo)
+			}
+		})
+	}
+}
+
+func TestRetryDelay(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+
+	tests := []struct {
+		name       string
+		retryAfter string
+		reset      string
+		attempt    int
+		want       time.Duration
+	}{
+		{
+			name:       "retry-after wins",
+			retryAfter: "30",
+			reset:      "1700000100",
+			want:       30 * time.Second,
+		},
+		{
+			name:  "waits until rate limit reset",
+			reset: "1700000060",
+			want:  61 * time.Second,
+		},
+		{
+			name:  "reset already passed",
+			reset: "1699999990",
+			want:  0,
+		},
+		{
+			name:    "exponential backoff without headers",
+			attempt: 2,
+			want:    4 * time.Second,
+		},
+		{
+			name:       "unparseable retry-after falls back to backoff",
+			retryAfter: "soon",
+			want:       time.Second,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := RetryDelay(tt.retryAfter, tt.reset, tt.attempt, now); got != tt.want {
+				t.Errorf("RetryDelay() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPermissionRank(t *testing.T) {
+	ordered := []string{"admin", "maintain", "write", "triage", "read", "custom"}
+	for i := 1; i < len(ordered); i++ {
+		if PermissionRank(ordered[i-1]) <= PermissionRank(ordered[i]) {
+			t.Errorf("PermissionRank(%q) should outrank PermissionRank(%q)", ordered[i-1], ordered[i])
+		}
+	}
+
+	aliases := map[string]string{"push": "write", "pull": "read"}
+	for alias, permission := range aliases {
+		if PermissionRank(alias) != PermissionRank(permission) {
+			t.Errorf("PermissionRank(%q) = %d, want %d", alias, PermissionRank(alias), PermissionRank(permission))
+		}
+	}
+}
+
+func TestTruncate(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		max   int
+		want  string
+	}{
+		{name: "short value", value: "main", max: 10, want: "main"},
+		{name: "exact fit", value: "main", max: 4, want: "main"},
+		{name: "cut with ellipsis", value: "https://ci.example.com/hook", max: 10, want: "https://c…"},
+		{name: "multibyte runes", value: "日本語のラベル", max: 4, want: "日本語…"},
+		{name: "no room", value: "release/*", max: 0, want: "…"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Truncate(tt.value, tt.max); got != tt.want {
+				t.Errorf("Truncate(%q, %d) = %q, want %q", tt.value, tt.max, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPlainOutput(t *testing.T) {
+	PlainOutput = true
+	defer func() { PlainOutput = false }()
+
+	tests := []struct {
+		name string
+		got  string
+		want string
+	}{
+		{
+			name: "true value",
+			got:  BoolToIcon(true),
+			want: "yes",
+		},
+		{
+			name: "false value",
+			got:  BoolToIcon(false),
+			want: "no",
+		},
+		{
+			name: "push permission",
+			got:  PermissionToIcon("push"),
+			want: "write",
+		},
+		{
+			name: "admin permission",
+			got:  PermissionToIcon("admin"),
+			want: "admin",
+		},
+		{
+			name: "icon",
+			got:  Icon("🔒 "),
+			want: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.got != tt.want {
+				t.Errorf("got %v, want %v", tt.got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEscapeMarkdownCell(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{
+			name:  "plain value",
+			input: "main",
+			want:  "main",
+		},
+		{
+			name:  "pipe character",
+			input: "a|b",
+			want:  "a\\|b",
+		},
+		{
+			name:  "newlines",
+			input: "line one\nline two\r\nline three",
+			want:  "line one line two line three",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := EscapeMarkdownCell(tt.input)
+			if got != tt.want {
+				t.Errorf("EscapeMarkdownCell() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseRepoArg(t *testing.T) {
+	tests := []struct {
+		name      string
+		arg       string
+		wantHost  string
+		wantOwner string
+		wantRepo  string
+		wantErr   bool
+	}{
+		{
+			name:      "owner and repo",
+			arg:       "cli/cli",
+			wantOwner: "cli",
+			wantRepo:  "cli",
+		},
+		{
+			name:      "host prefix",
+			arg:       "github.example.com/owner/repo",
+			wantHost:  "github.example.com",
+			wantOwner: "owner",
+			wantRepo:  "repo",
+		},
+		{
+			name:      "full URL with .git suffix",
+			arg:       "https://github.example.com/owner/repo.git",
+			wantHost:  "github.example.com",
+			wantOwner: "owner",
+			wantRepo:  "repo",
+		},
+		{
+			name:    "missing repo",
+			arg:     "owner",
+			wantErr: true,
+		},
+		{
+			name:    "empty owner",
+			arg:     "/repo",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			host, owner, repo, err := ParseRepoArg(tt.arg)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseRepoArg() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if host != tt.wantHost || owner != tt.wantOwner || repo != tt.wantRepo {
+				t.Errorf("ParseRepoArg() = %q, %q, %q, want %q, %q, %q", host, owner, repo, tt.wantHost, tt.wantOwner, tt.wantRepo)
+			}
+		})
+	}
+}
+
+func TestParseCodeOwnersLine(t *testing.T) {
+	tests := []struct {
+		name        string
+		line        string
+		wantPattern string
+		wantOwners  []string
+		wantOK      bool
+	}{
+		{
+			name:        "pattern with owners",
+			line:        "*.go @org/backend alice",
+			wantPattern: "*.go",
+			wantOwners:  []string{"@org/backend", "alice"},
+			wantOK:      true,
+		},
+		{
+			name:        "trailing comment",
+			line:        "/docs/ @org/docs # documentation",
+			wantPattern: "/docs/",
+			wantOwners:  []string{"@org/docs"},
+			wantOK:      true,
+		},
+		{
+			name:        "pattern without owners",
+			line:        "/vendor/",
+			wantPattern: "/vendor/",
+			wantOwners:  []string{},
+			wantOK:      true,
+		},
+		{
+			name:   "comment line",
+			line:   "# global owners",
+			wantOK: false,
+		},
+		{
+			name:   "blank line",
+			line:   "   ",
+			wantOK: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pattern, owners, ok := ParseCodeOwnersLine(tt.line)
+			if ok != tt.wantOK {
+				t.Fatalf("ParseCodeOwnersLine() ok = %v, want %v", ok, tt.wantOK)
+			}
+			if !ok {
+				return
+			}
+			if pattern != tt.wantPattern || strings.Join(owners, ",") != strings.Join(tt.wantOwners, ",") {
+				t.Errorf("ParseCodeOwnersLine() = %q, %v, want %q, %v", pattern, owners, tt.wantPattern, tt.wantOwners)
+			}
+		})
+	}
+}
+
+func TestCheckNameMatches(t *testing.T) {
+	tests := []struct {
+		required string
+		name     string
+		want     bool
+	}{
+		{"build", "build", true},
+		{"Build", "build ", true},
+		{"CI / test", "test", true},
+		{"ci/circleci: lint", "ci/circleci: lint", true},
+		{"test", "test (ubuntu-latest)", false},
+		{"test", "CI", false},
+		{"CI / test", "CI", false},
+	}
+
+	for _, tt := range tests {
+		if got := CheckNameMatches(tt.required, tt.name); got != tt.want {
+			t.Errorf("CheckNameMatches(%q, %q) = %v, want %v", tt.required, tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestNormalizeTimestamp(t *testing.T) {
+	tests := []struct {
+		value string
+		want  string
+	}{
+		{"2024-03-01T10:15:00Z", "2024-03-01T10:15:00Z"},
+		{"2024-03-01T10:15:00.123Z", "2024-03-01T10:15:00Z"},
+		{"2024-03-01T12:15:00.000+02:00", "2024-03-01T10:15:00Z"},
+		{"", ""},
+		{"yesterday", "yesterday"},
+	}
+
+	for _, tt := range tests {
+		if got := NormalizeTimestamp(tt.value); got != tt.want {
+			t.Errorf("NormalizeTimestamp(%q) = %q, want %q", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestLastPage(t *testing.T) {
+	tests := []struct {
+		name     string
+		link     string
+		wantPage int
+		wantOK   bool
+	}{
+		{
+			name:     "next and last",
+			link:     `<https://api.github.com/repositories/1/secret-scanning/alerts?state=open&per_page=1&page=2>; rel="next", <https://api.github.com/repositories/1/secret-scanning/alerts?state=open&per_page=1&page=42>; rel="last"`,
+			wantPage: 42,
+			wantOK:   true,
+		},
+		{
+			name: "no header",
+			link: "",
+		},
+		{
+			name: "last page has no last link",
+			link: `<https://api.github.com/repositories/1/secret-scanning/alerts?per_page=1&page=1>; rel="first", <https://api.github.com/repositories/1/secret-scanning/alerts?per_page=1&page=41>; rel="prev"`,
+		},
+		{
+			name: "cursor pagination",
+			link: `<https://api.github.com/repositories/1/dependabot/alerts?per_page=1&after=Y3Vyc29y>; rel="next"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			page, ok := LastPage(tt.link)
+			if page != tt.wantPage || ok != tt.wantOK {
+				t.Errorf("LastPage() = %d, %v, want %d, %v", page, ok, tt.wantPage, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestFlattenTree(t *testing.T) {
+	tree := `Repository Governance Report
+═══════════════════════════
+
+Repository: octo/demo
+
+Repository Rulesets (1)
+└─ main (Pattern: main)
+   ├─ Require PR Reviews: Yes
+   │  └─ Required Approving Reviews: 2
+   ├─ Bypass Actors:
+   │  └─ @admins (always)
+   └─ Required Status Checks: None
+
+Labels (2)
+├─ bug: Something isn't working
+└─ docs
+`
+	want := `Repository: octo/demo
+Repository Rulesets (1) > main (Pattern: main)
+Repository Rulesets (1) > main > Require PR Reviews: Yes
+Repository Rulesets (1) > main > Require PR Reviews > Required Approving Reviews: 2
+Repository Rulesets (1) > main > Bypass Actors > @admins (always)
+Repository Rulesets (1) > main > Required Status Checks: None
+Labels (2) > bug: Something isn't working
+Labels (2) > docs
+`
+	if got := FlattenTree(tree); got != want {
+		t.Errorf("FlattenTree() =\n%s\nwant\n%s", got, want)
+	}
+}
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"time"
+)
+
+// watchRecord is one NDJSON line of --watch output: the inspected
+// configuration plus when it was taken and what changed since the last cycle
+type watchRecord struct {
+	Timestamp string       `json:"timestamp"`
+	Changes   []difference `json:"changes,omitempty"`
+	*GovernanceConfig
+}
+
+// runWatch re-inspects a repository every interval and emits a record per
+// cycle until interrupted. An interrupt during a cycle lets it finish so the
+// final record is written completely.
+func runWatch(owner, repo string) error {
+	if watchInterval <= 0 {
+		return fmt.Errorf("--interval must be positive")
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	return writeReport(func(w io.Writer) error {
+		var previous *GovernanceConfig
+		for {
+			logger.Info(fmt.Sprintf("Inspecting repository: %s/%s", owner, repo), repoAttr(owner, repo))
+
+			governance, err := inspectRepository(owner, repo)
+			if err != nil {
+				return fmt.Errorf("failed to inspect repository: %v", err)
+			}
+			// Redacted before comparing so changes don't reveal masked values
+			governance = redact(governance, redactCategories)
+
+			record := watchRecord{Timestamp: time.Now().UTC().Format(time.RFC3339), GovernanceConfig: governance}
+			first := previous == nil
+			if !first {
+				record.Changes = compareGovernance(withoutActivity(previous), withoutActivity(governance), false)
+			}
+			previous = governance
+
+			// The first cycle is always emitted as the baseline
+			if first || !watchChangesOnly || len(record.Changes) > 0 {
+				if err := outputNDJSON(w, record); err != nil {
+					return err
+				}
+			}
+
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-time.After(watchInterval):
+			}
+		}
+	})
+}
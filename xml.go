@@ -0,0 +1,110 @@
+package main
+
+import (
+	"encoding/xml"
+	"io"
+	"reflect"
+	"sort"
+)
+
+// settingSources maps a security setting's JSON name to the source of its
+// value. encoding/xml can't encode maps, so it encodes as one <source>
+// element per setting, in key order so the output is stable.
+type settingSources map[string]string
+
+func (s settingSources) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return marshalMapXML(e, start, s, "source", "setting")
+}
+
+// ruleTypeCounts maps a rule type to the number of rulesets enforcing it,
+// encoded as one <rule_type name="..."> element per type
+type ruleTypeCounts map[string]int
+
+func (c ruleTypeCounts) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return marshalMapXML(e, start, c, "rule_type", "name")
+}
+
+// marshalMapXML encodes a map as one element per key, with the key in attr
+// and the value as text
+func marshalMapXML[V any](e *xml.Encoder, start xml.StartElement, values map[string]V, element, attr string) error {
+	if len(values) == 0 {
+		return nil
+	}
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	if err := e.EncodeToken(start); err != nil {
+		return err
+	}
+	for _, key := range keys {
+		child := xml.StartElement{
+			Name: xml.Name{Local: element},
+			Attr: []xml.Attr{{Name: xml.Name{Local: attr}, Value: key}},
+		}
+		if err := e.EncodeElement(values[key], child); err != nil {
+			return err
+		}
+	}
+	return e.EncodeToken(start.End())
+}
+
+// xmlReport is a GovernanceConfig as an XML document. encoding/xml always
+// writes struct fields, so the always-present settings sections are
+// shadowed by pointers that are nil when --sections filtered them away.
+type xmlReport struct {
+	XMLName xml.Name `xml:"repository_governance"`
+	*GovernanceConfig
+	SecuritySettings *SecuritySettings   `xml:"security_settings,omitempty"`
+	RepoSettings     *RepositorySettings `xml:"repository_settings,omitempty"`
+}
+
+// xmlReports holds the reports of a batch under a single root element
+type xmlReports struct {
+	XMLName xml.Name    `xml:"repositories"`
+	Reports []xmlReport `xml:"repository_governance"`
+}
+
+// xmlDocument returns the XML form of a report, with the sections outside
+// --sections cleared on a copy so the report itself is left intact
+func xmlDocument(governance *GovernanceConfig, sectionsFilter []string) xmlReport {
+	filtered := *governance
+	value := reflect.ValueOf(&filtered).Elem()
+	for section, field := range fieldSectionAliases {
+		if !shouldIncludeSectionOutput(section, sectionsFilter) {
+			value.FieldByName(field).SetZero()
+		}
+	}
+	// Filled by the rulesets and releases fetchers alongside their sections
+	if !shouldIncludeSectionOutput("rulesets", sectionsFilter) {
+		filtered.RequiredChecks = nil
+	}
+	if !shouldIncludeSectionOutput("releases", sectionsFilter) {
+		filtered.TagProtections = nil
+	}
+
+	report := xmlReport{GovernanceConfig: &filtered}
+	if shouldIncludeSectionOutput("security", sectionsFilter) {
+		report.SecuritySettings = &filtered.SecuritySettings
+	}
+	if shouldIncludeSectionOutput("settings", sectionsFilter) {
+		report.RepoSettings = &filtered.RepoSettings
+	}
+	return report
+}
+
+// outputXML writes value as an indented XML document with its declaration
+func outputXML(w io.Writer, value interface{}) error {
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(value); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}